@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// breakEven implements the breakeven command, which compares reserved
+// instance terms against running on demand for a usage profile.
+func breakEven(args []string) {
+	fs := flag.NewFlagSet("breakeven", flag.ExitOnError)
+	utilization := fs.Float64("utilization", 100, "Expected utilization, as a percentage of the hours in a year")
+	monthlyHours := fs.String("monthly-hours", "", "Expected hours per month: one value for every month, or 12 comma separated values (overrides -utilization)")
+	terms := fs.String("ri", "all", "Comma separated reserved instance terms to compare, as LENGTH-CLASS-OPTION, or \"all\"")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] breakeven [breakeven flags] [type pattern...]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *utilization < 0 || *utilization > 100 {
+		log.Fatalf("-utilization must be between 0 and 100")
+	}
+	hours := *utilization / 100 * pricing.HoursPerYear
+	if *monthlyHours != "" {
+		var err error
+		hours, err = parseMonthlyHours(*monthlyHours)
+		checkErr(err, "-monthly-hours")
+	}
+	riTerms := riTerms(*terms)
+	render := tableFormats[*outFormat]
+	if render == nil {
+		log.Fatalf("breakeven cannot write -format %s", *outFormat)
+	}
+
+	opts := loadOptions()
+	opts.Types = fs.Args()
+	sortKeys, err := parseSortKeys(*sortSpec)
+	checkErr(err, "-sort")
+	for _, k := range sortKeys {
+		setupFields(&opts, k.f)
+	}
+	instances := loadInstances(opts)
+	sortInstances(instances, sortKeys)
+
+	if *outFormat == "col" {
+		fmt.Printf("profile: %.0f hours/year (%.1f%% utilization)\n", hours, hours/pricing.HoursPerYear*100)
+	}
+	t := breakEvenTable(instances, riTerms, hours, len(regionList()) != 1)
+	checkErr(render(os.Stdout, t), "Write "+*outFormat)
+}
+
+// breakEvenTable returns a row for each instance type and reserved term it
+// has, comparing the term against running on demand for hours a year.
+// multiRegion adds a region column.
+func breakEvenTable(instances []pricing.InstanceType, terms []pricing.RITerm, hours float64, multiRegion bool) *table {
+	t := &table{}
+	if multiRegion {
+		t.cols = append(t.cols, column{name: "region"})
+	}
+	for _, name := range []string{"type", "term"} {
+		t.cols = append(t.cols, column{name: name})
+	}
+	for _, name := range []string{"on-demand", "reserved", "break-even", "savings", "savings%"} {
+		t.cols = append(t.cols, column{name: name, numeric: true})
+	}
+
+	for _, in := range instances {
+		onDemand := in.Hourly * hours
+		for _, term := range terms {
+			rp, ok := in.ReservedPrice(term)
+			if !ok {
+				continue
+			}
+			savings := onDemand - rp.EffectiveAnnual
+			var savingsPct float64
+			if onDemand > 0 {
+				savingsPct = savings / onDemand * 100
+			}
+			breakEven := "never"
+			if be := rp.BreakEven(in.Hourly); be <= 1 {
+				breakEven = fmt.Sprintf("%.1f%%", be*100)
+			}
+
+			var row []string
+			if multiRegion {
+				row = append(row, in.Region)
+			}
+			row = append(row, in.Name, term.String(),
+				fmt.Sprintf("%.2f", onDemand), fmt.Sprintf("%.2f", rp.EffectiveAnnual),
+				breakEven, fmt.Sprintf("%.2f", savings), fmt.Sprintf("%.1f", savingsPct))
+			t.rows = append(t.rows, row)
+		}
+	}
+	return t
+}
+
+// parseMonthlyHours returns the hours in a year from a single monthly value
+// or 12 comma separated ones.
+func parseMonthlyHours(s string) (float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 1 && len(parts) != 12 {
+		return 0, fmt.Errorf("want 1 or 12 values, got %d", len(parts))
+	}
+
+	var total float64
+	for _, p := range parts {
+		h, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return 0, err
+		}
+		if h < 0 || h > 744 {
+			return 0, fmt.Errorf("%v hours is not within a month", h)
+		}
+		total += h
+	}
+	if len(parts) == 1 {
+		total *= 12
+	}
+	if total > pricing.HoursPerYear {
+		log.Printf("-monthly-hours adds up to more than a year, capping at %.0f", pricing.HoursPerYear)
+		total = pricing.HoursPerYear
+	}
+	return total, nil
+}
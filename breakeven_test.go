@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestBreakEvenTable(t *testing.T) {
+	term := pricing.DefaultRITerm
+	instances := []pricing.InstanceType{
+		{Name: "m5.large", Region: "us-east-1", Hourly: 0.096, Reserved: []pricing.ReservedPrice{{Term: term, Hourly: 0.07, EffectiveAnnual: 613.2}}},
+		{Name: "u7in-24tb.224xlarge", Region: "eu-west-1", Hourly: 300, Reserved: []pricing.ReservedPrice{{Term: term, Hourly: 300, EffectiveAnnual: 2628000}}},
+		{Name: "m7g.large", Region: "us-east-1", Hourly: 0.0816},
+	}
+
+	var buf bytes.Buffer
+	if err := renderCol(&buf, breakEvenTable(instances, []pricing.RITerm{term}, pricing.HoursPerYear, true)); err != nil {
+		t.Fatal(err)
+	}
+	exp := `   region                type          term  on-demand   reserved break-even savings savings%
+us-east-1            m5.large 1yr-conv-none     840.96     613.20      72.9%  227.76     27.1
+eu-west-1 u7in-24tb.224xlarge 1yr-conv-none 2628000.00 2628000.00     100.0%    0.00      0.0
+`
+	if got := buf.String(); got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+}
@@ -0,0 +1,508 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A column is one column of the listing.
+type column struct {
+	name    string
+	numeric bool // right aligned in markdown and sorted numerically in html
+	cost    bool // a price, so better lower under -pareto-dims
+
+	// text is the value shown in the col format, and in csv unless csv is
+	// set. json is the value in the json format; nil encodes as null.
+	text func(in pricing.InstanceType) string
+	csv  func(in pricing.InstanceType) string
+	json func(in pricing.InstanceType) interface{}
+
+	// setup, if set, adjusts the load options for what the column shows.
+	setup func(opts *pricing.Options)
+}
+
+func (c column) csvText(in pricing.InstanceType) string {
+	if c.csv != nil {
+		return c.csv(in)
+	}
+	return c.text(in)
+}
+
+func strColumn(name string, f func(in pricing.InstanceType) string) column {
+	return column{
+		name: name,
+		text: f,
+		json: func(in pricing.InstanceType) interface{} { return f(in) },
+	}
+}
+
+// floatColumn returns a column of f formatted with format, or "-" and null
+// where f is NaN.
+func floatColumn(name string, format string, f func(in pricing.InstanceType) float64) column {
+	return column{
+		name:    name,
+		numeric: true,
+		text: func(in pricing.InstanceType) string {
+			v := f(in)
+			if math.IsNaN(v) {
+				return "-"
+			}
+			return fmt.Sprintf(format, v)
+		},
+		json: func(in pricing.InstanceType) interface{} {
+			v := f(in)
+			if math.IsNaN(v) {
+				return nil
+			}
+			return v
+		},
+	}
+}
+
+// nullPrice returns p, or nil if it is not a price.
+func nullPrice(p float64) interface{} {
+	if !priceOK(p) {
+		return nil
+	}
+	return p
+}
+
+// costColumn returns a floatColumn of a price.
+func costColumn(name string, format string, f func(in pricing.InstanceType) float64) column {
+	c := floatColumn(name, format, f)
+	c.cost = true
+	return c
+}
+
+// defaultColumns are listed when -columns is not given, followed by the
+// columns of -os-premium, -ri, -sp, -spot-history and host tenancy.
+var defaultColumns = []string{"type", "mem", "vcpu", "disk", "mfg", "net", "hourly", "annual", "annual-reserved"}
+
+// hostColumns replace defaultColumns for Dedicated Hosts, which have no
+// memory, storage or network of their own to list.
+var hostColumns = []string{"type", "vcpu", "cores", "mfg", "hourly", "annual", "max-instances"}
+
+// namedColumns are the columns -columns can select besides those built from
+// reserved, Savings Plans and spot terms.
+var namedColumns = map[string]column{
+	"region": strColumn("region", func(in pricing.InstanceType) string { return in.Region }),
+	"type":   strColumn("type", func(in pricing.InstanceType) string { return in.Name }),
+	"family": strColumn("family", func(in pricing.InstanceType) string { return in.Family }),
+	"mem": {
+		name:    "mem",
+		numeric: true,
+		text:    func(in pricing.InstanceType) string { return fmt.Sprintf("%.1f", in.Memory) },
+		csv:     func(in pricing.InstanceType) string { return toS(in.Memory) },
+		json:    func(in pricing.InstanceType) interface{} { return in.Memory },
+	},
+	"vcpu": {
+		name:    "vcpu",
+		numeric: true,
+		text:    func(in pricing.InstanceType) string { return toS(in.VCPU) },
+		json:    func(in pricing.InstanceType) interface{} { return in.VCPU },
+	},
+	"disk": strColumn("disk", func(in pricing.InstanceType) string { return in.Disk.String() }),
+	"mfg":  strColumn("mfg", func(in pricing.InstanceType) string { return in.CPUMfgr.String() }),
+	"net":  strColumn("net", func(in pricing.InstanceType) string { return in.NetworkPerf.String() }),
+	"hourly": {
+		name:    "hourly",
+		numeric: true,
+		cost:    true,
+		text:    func(in pricing.InstanceType) string { return fmt.Sprintf("%.4f", in.Hourly) },
+		csv:     func(in pricing.InstanceType) string { return toS(in.Hourly) },
+		json:    func(in pricing.InstanceType) interface{} { return nullPrice(in.Hourly) },
+	},
+	"annual": {
+		name:    "annual",
+		numeric: true,
+		cost:    true,
+		text:    func(in pricing.InstanceType) string { return fmt.Sprintf("%.2f", in.OnDemandAnnual) },
+		csv:     func(in pricing.InstanceType) string { return toS(in.OnDemandAnnual) },
+		json:    func(in pricing.InstanceType) interface{} { return nullPrice(in.OnDemandAnnual) },
+	},
+	"annual-reserved": {
+		name:    "annual-reserved",
+		numeric: true,
+		cost:    true,
+		text:    func(in pricing.InstanceType) string { return fmt.Sprintf("%.2f", in.ReservedAnnual) },
+		csv:     func(in pricing.InstanceType) string { return toS(in.ReservedAnnual) },
+		json:    func(in pricing.InstanceType) interface{} { return nullPrice(in.ReservedAnnual) },
+	},
+
+	// Derived columns.
+	"vcpu-hr": costColumn("vcpu-hr", "%.5f", func(in pricing.InstanceType) float64 {
+		return ratio(price(in.Hourly), float64(in.VCPU))
+	}),
+	"gib-hr": costColumn("gib-hr", "%.5f", func(in pricing.InstanceType) float64 {
+		return ratio(price(in.Hourly), in.Memory)
+	}),
+	"mem/vcpu": floatColumn("mem/vcpu", "%.1f", func(in pricing.InstanceType) float64 {
+		return ratio(in.Memory, float64(in.VCPU))
+	}),
+	"monthly": costColumn("monthly", "%.2f", func(in pricing.InstanceType) float64 {
+		return price(in.Hourly) * hoursPerMonth
+	}),
+	"3yr": costColumn("3yr", "%.2f", func(in pricing.InstanceType) float64 {
+		return price(in.Hourly) * pricing.HoursPerYear * 3
+	}),
+	"ri-savings-%": floatColumn("ri-savings-%", "%.1f", func(in pricing.InstanceType) float64 {
+		if in.ReservedAnnual == 0 {
+			return math.NaN()
+		}
+		return (1 - ratio(in.ReservedAnnual, in.OnDemandAnnual)) * 100
+	}),
+
+	// Provenance columns.
+	"sku":              strColumn("sku", func(in pricing.InstanceType) string { return in.SKU }),
+	"version":          strColumn("version", func(in pricing.InstanceType) string { return in.Version }),
+	"publication-date": strColumn("publication-date", func(in pricing.InstanceType) string { return in.PublicationDate }),
+	"term-code":        strColumn("term-code", func(in pricing.InstanceType) string { return in.HourlySource.OfferTermCode }),
+	"rate-code":        strColumn("rate-code", func(in pricing.InstanceType) string { return in.HourlySource.RateCode }),
+	"effective-date":   strColumn("effective-date", func(in pricing.InstanceType) string { return in.HourlySource.EffectiveDate }),
+	"reserved-term-code": strColumn("reserved-term-code", func(in pricing.InstanceType) string {
+		return defaultReservedSource(in).OfferTermCode
+	}),
+	"reserved-rate-code": strColumn("reserved-rate-code", func(in pricing.InstanceType) string {
+		return defaultReservedSource(in).RateCode
+	}),
+	"reserved-effective-date": strColumn("reserved-effective-date", func(in pricing.InstanceType) string {
+		return defaultReservedSource(in).EffectiveDate
+	}),
+
+	"os-premium": osPremiumColumn(),
+	"cores": floatColumn("cores", "%.0f", func(in pricing.InstanceType) float64 {
+		cores, err := strconv.ParseFloat(in.Attributes.PhysicalCores, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return cores
+	}),
+	"max-instances": floatColumn("max-instances", "%.0f", func(in pricing.InstanceType) float64 {
+		most := math.NaN()
+		for _, f := range in.HostFits {
+			if math.IsNaN(most) || float64(f.Count) > most {
+				most = float64(f.Count)
+			}
+		}
+		return most
+	}),
+	"fits": {
+		name: "fits",
+		text: func(in pricing.InstanceType) string { return formatHostFits(in.HostFits) },
+		json: func(in pricing.InstanceType) interface{} {
+			fits := make(map[string]int)
+			for _, f := range in.HostFits {
+				fits[f.Type] = f.Count
+			}
+			return fits
+		},
+	},
+}
+
+// osPremiumColumn returns the column of the on-demand price over plain
+// Linux, or "-" and null where either price is missing.
+func osPremiumColumn() column {
+	c := costColumn("os-premium", "%.4f", func(in pricing.InstanceType) float64 {
+		return price(in.Hourly) - price(in.LinuxHourly)
+	})
+	c.setup = func(opts *pricing.Options) { opts.LinuxPremium = true }
+	return c
+}
+
+// provenanceColumns are added by -provenance.
+var provenanceColumns = []string{
+	"sku", "version", "publication-date", "term-code", "rate-code", "effective-date",
+	"reserved-term-code", "reserved-rate-code", "reserved-effective-date",
+}
+
+// defaultReservedSource returns where the ReservedAnnual price of in was
+// read from.
+func defaultReservedSource(in pricing.InstanceType) pricing.PriceSource {
+	rp, _ := in.ReservedPrice(pricing.DefaultRITerm)
+	return rp.Source
+}
+
+// hoursPerMonth is the number of hours monthly costs are calculated over,
+// as on AWS bills.
+const hoursPerMonth = 730
+
+// ratio returns a/b, or NaN if b is zero.
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return math.NaN()
+	}
+	return a / b
+}
+
+// selectColumns returns the columns to list: those named by -columns, or
+// the default columns and those added by other flags, followed by any
+// -provenance columns not already listed. The load options are adjusted for
+// them.
+func selectColumns(opts *pricing.Options, multiRegion bool) []column {
+	var cols []column
+	if *columnSpec != "" {
+		for _, name := range strings.Split(*columnSpec, ",") {
+			c, err := columnByName(strings.TrimSpace(name))
+			checkErr(err, "-columns")
+			cols = append(cols, c)
+		}
+	} else {
+		names := defaultColumns
+		if opts.Tenancy == pricing.TenancyHost {
+			names = hostColumns
+		}
+		if multiRegion {
+			names = append([]string{"region"}, names...)
+		}
+		if *osPremium {
+			names = append(names, "os-premium")
+		}
+		for _, name := range names {
+			cols = append(cols, namedColumns[name])
+		}
+		cols = append(cols, reservedColumns()...)
+		cols = append(cols, savingsPlanColumns()...)
+		cols = append(cols, spotColumns()...)
+		cols = append(cols, paretoColumns()...)
+		if opts.Tenancy == pricing.TenancyHost {
+			cols = append(cols, namedColumns["fits"])
+		}
+	}
+
+	if *provenance {
+		for _, name := range provenanceColumns {
+			if !slices.ContainsFunc(cols, func(c column) bool { return c.name == name }) {
+				cols = append(cols, namedColumns[name])
+			}
+		}
+	}
+
+	for _, c := range cols {
+		if c.setup != nil {
+			c.setup(opts)
+		}
+	}
+	return cols
+}
+
+// columnByName returns a named column, or the column for a reserved term
+// value (e.g. 3yr-std-all-annual), a Savings Plans term (e.g.
+// sp-compute-1yr-none) or a spot value.
+func columnByName(name string) (column, error) {
+	if c, ok := namedColumns[name]; ok {
+		return c, nil
+	}
+
+	switch {
+	case name == "spot-price" || name == "spot-discount-%":
+		if *spotHistory == "" {
+			return column{}, fmt.Errorf("%s needs -spot-history", name)
+		}
+		for _, c := range spotColumns() {
+			if c.name == name {
+				return c, nil
+			}
+		}
+	case name == "dominated-by":
+		if !*paretoAnnotate {
+			return column{}, fmt.Errorf("%s needs -pareto-annotate", name)
+		}
+		return dominatedByColumn(nil), nil
+	case strings.HasPrefix(name, "sp-"):
+		term, err := pricing.ParseSPTerm(strings.TrimPrefix(name, "sp-"))
+		if err != nil {
+			return column{}, err
+		}
+		return savingsPlanColumn(term), nil
+	case strings.Count(name, "-") == 3:
+		i := strings.LastIndex(name, "-")
+		term, err := pricing.ParseRITerm(name[:i])
+		if err != nil {
+			return column{}, err
+		}
+		return reservedColumn(term, name[i+1:])
+	}
+
+	var names []string
+	for n := range namedColumns {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	names = append(names, "TERM-upfront", "TERM-hourly", "TERM-annual", "sp-TERM", "spot-price", "spot-discount-%", "dominated-by")
+	return column{}, fmt.Errorf("unknown column %q (columns: %s)", name, strings.Join(names, ", "))
+}
+
+// riTerms parses a comma separated list of reserved instance terms, or
+// "all".
+func riTerms(spec string) []pricing.RITerm {
+	if spec == "all" {
+		return pricing.AllRITerms
+	}
+
+	var terms []pricing.RITerm
+	for _, s := range strings.Split(spec, ",") {
+		term, err := pricing.ParseRITerm(strings.TrimSpace(s))
+		checkErr(err, "-ri")
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// reservedColumns returns the columns for -ri and -ri-values.
+func reservedColumns() []column {
+	if *riSpec == "" {
+		return nil
+	}
+
+	var cols []column
+	for _, term := range riTerms(*riSpec) {
+		for _, value := range strings.Split(*riValues, ",") {
+			c, err := reservedColumn(term, value)
+			checkErr(err, "-ri-values")
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// reservedColumn returns the column of one value of a reserved term:
+// upfront, hourly or annual.
+func reservedColumn(term pricing.RITerm, value string) (column, error) {
+	values := map[string]struct {
+		format string
+		value  func(rp pricing.ReservedPrice) float64
+	}{
+		"upfront": {"%.2f", func(rp pricing.ReservedPrice) float64 { return rp.Upfront }},
+		"hourly":  {"%.4f", func(rp pricing.ReservedPrice) float64 { return rp.Hourly }},
+		"annual":  {"%.2f", func(rp pricing.ReservedPrice) float64 { return rp.EffectiveAnnual }},
+	}
+	v, ok := values[value]
+	if !ok {
+		return column{}, fmt.Errorf("unknown reserved value %q (want upfront, hourly or annual)", value)
+	}
+
+	name := term.String() + "-" + value
+	return costColumn(name, v.format, func(in pricing.InstanceType) float64 {
+		rp, ok := in.ReservedPrice(term)
+		if !ok {
+			return math.NaN()
+		}
+		return v.value(rp)
+	}), nil
+}
+
+// savingsPlanColumns returns the columns for -sp.
+func savingsPlanColumns() []column {
+	if *spSpec == "" {
+		return nil
+	}
+
+	terms := pricing.AllSPTerms
+	if *spSpec != "all" {
+		terms = nil
+		for _, s := range strings.Split(*spSpec, ",") {
+			term, err := pricing.ParseSPTerm(strings.TrimSpace(s))
+			checkErr(err, "-sp")
+			terms = append(terms, term)
+		}
+	}
+
+	var cols []column
+	for _, term := range terms {
+		cols = append(cols, savingsPlanColumn(term))
+	}
+	return cols
+}
+
+// savingsPlanColumn returns the column of the hourly rate under a Savings
+// Plans term.
+func savingsPlanColumn(term pricing.SPTerm) column {
+	name := "sp-" + term.String()
+	c := costColumn(name, "%.4f", func(in pricing.InstanceType) float64 {
+		sp, ok := in.SavingsPlanPrice(term)
+		if !ok {
+			return math.NaN()
+		}
+		return sp.Hourly
+	})
+	c.setup = func(opts *pricing.Options) { opts.SavingsPlans = true }
+	return c
+}
+
+// spotColumns returns the columns for -spot-history and -spot-value.
+func spotColumns() []column {
+	if *spotHistory == "" {
+		return nil
+	}
+
+	switch *spotValue {
+	case "latest", "min", "median":
+	default:
+		log.Fatalf("unknown -spot-value %q", *spotValue)
+	}
+
+	return []column{
+		costColumn("spot-price", "%.4f", func(in pricing.InstanceType) float64 {
+			if in.Spot == nil {
+				return math.NaN()
+			}
+			return spotPrice(in.Spot)
+		}),
+		floatColumn("spot-discount-%", "%.1f", func(in pricing.InstanceType) float64 {
+			if in.Spot == nil || in.Hourly == 0 {
+				return math.NaN()
+			}
+			return (1 - spotPrice(in.Spot)/in.Hourly) * 100
+		}),
+	}
+}
+
+// spotPrice returns the -spot-value of sp.
+func spotPrice(sp *pricing.SpotPrice) float64 {
+	switch *spotValue {
+	case "min":
+		return sp.Min
+	case "median":
+		return sp.Median
+	}
+	return sp.Latest
+}
+
+// columnObject returns in as a JSON object of cols, in column order, keyed
+// by jsonKey of the column names.
+func columnObject(cols []column, in pricing.InstanceType) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, _ := json.Marshal(jsonKey(c.name))
+		v, err := json.Marshal(c.json(in))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteString(":")
+		buf.Write(v)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+var jsonKeyReplacer = strings.NewReplacer("-%", "_pct", "/", "_per_", "-", "_")
+
+// jsonKey returns the lower_snake JSON key of a column name, e.g.
+// "mem_per_vcpu" for "mem/vcpu".
+func jsonKey(name string) string {
+	return jsonKeyReplacer.Replace(name)
+}
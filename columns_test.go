@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestColumns(t *testing.T) {
+	in := pricing.InstanceType{
+		Name:           "m5.large",
+		VCPU:           2,
+		Memory:         8,
+		Hourly:         0.096,
+		OnDemandAnnual: 0.096 * pricing.HoursPerYear,
+		ReservedAnnual: 0.072 * pricing.HoursPerYear,
+		Reserved: []pricing.ReservedPrice{
+			{Term: pricing.RITerm{Length: "3yr", Class: "standard", PurchaseOption: "All Upfront"}, Upfront: 1000, EffectiveAnnual: 333.33},
+		},
+	}
+
+	tests := []struct {
+		name string
+		exp  string
+	}{
+		{"vcpu-hr", "0.04800"},
+		{"gib-hr", "0.01200"},
+		{"mem/vcpu", "4.0"},
+		{"monthly", "70.08"},
+		{"3yr", "2522.88"},
+		{"ri-savings-%", "25.0"},
+		{"3yr-std-all-upfront", "1000.00"},
+		{"1yr-std-all-upfront", "-"},
+		{"sp-compute-1yr-none", "-"},
+	}
+	for _, test := range tests {
+		c, err := columnByName(test.name)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if got := c.text(in); got != test.exp {
+			t.Errorf("%s: got=%q exp=%q", test.name, got, test.exp)
+		}
+	}
+
+	for _, name := range []string{"bogus", "3yr-std-all-monthly", "sp-compute-2yr-none"} {
+		if _, err := columnByName(name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestColumnObject(t *testing.T) {
+	var cols []column
+	for _, name := range []string{"type", "vcpu", "hourly", "annual", "annual-reserved", "monthly", "3yr", "vcpu-hr", "os-premium", "mem/vcpu"} {
+		c, err := columnByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, c)
+	}
+
+	obj, err := columnObject(cols, pricing.InstanceType{Name: "m7g.large", VCPU: 2, Memory: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"type":"m7g.large","vcpu":2,"hourly":null,"annual":null,"annual_reserved":null,"monthly":null,"3yr":null,"vcpu_hr":null,"os_premium":null,"mem_per_vcpu":4}`
+	if got := string(obj); got != exp {
+		t.Errorf("got=%s\nexp=%s", got, exp)
+	}
+
+	// A premium needs both prices.
+	obj, err = columnObject(cols[8:9], pricing.InstanceType{Name: "m7g.large", Hourly: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := string(obj), `{"os_premium":null}`; got != exp {
+		t.Errorf("got=%s\nexp=%s", got, exp)
+	}
+	if got := cols[8].text(pricing.InstanceType{Hourly: 0.1}); got != "-" {
+		t.Errorf("os-premium text got=%q exp=-", got)
+	}
+}
+
+func TestHostColumns(t *testing.T) {
+	host := pricing.InstanceType{
+		Name:       "m5",
+		VCPU:       96,
+		Attributes: pricing.ProductAttributes{PhysicalCores: "48"},
+		HostFits:   []pricing.HostFit{{Type: "m5.large", Count: 48}, {Type: "m5.24xlarge", Count: 1}},
+	}
+
+	cols := selectColumns(&pricing.Options{Tenancy: pricing.TenancyHost}, false)
+	var names []string
+	for _, c := range cols {
+		names = append(names, c.name)
+	}
+	if exp := "type,vcpu,cores,mfg,hourly,annual,max-instances,fits"; strings.Join(names, ",") != exp {
+		t.Errorf("host columns got=%s exp=%s", strings.Join(names, ","), exp)
+	}
+
+	for name, exp := range map[string]string{"cores": "48", "max-instances": "48", "fits": "m5.large:48 m5.24xlarge:1"} {
+		if got := namedColumns[name].text(host); got != exp {
+			t.Errorf("%s got=%q exp=%q", name, got, exp)
+		}
+	}
+	if got := namedColumns["cores"].text(pricing.InstanceType{}); got != "-" {
+		t.Errorf("cores without physicalCores got=%q exp=-", got)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// compareColumns are the attributes compare lists besides those in
+// namedColumns.
+var compareColumns = map[string]column{
+	"net-gbps":      floatColumn("net-gbps", "%.1f", func(in pricing.InstanceType) float64 { return in.NetworkPerf.CapGb }),
+	"disk-gb":       floatColumn("disk-gb", "%.0f", func(in pricing.InstanceType) float64 { return float64(in.Disk.Count * in.Disk.PerDiskGB) }),
+	"processor":     strColumn("processor", func(in pricing.InstanceType) string { return in.Attributes.PhysicalProcessor }),
+	"clock-speed":   strColumn("clock-speed", func(in pricing.InstanceType) string { return in.Attributes.ClockSpeed }),
+	"arch":          strColumn("arch", arch),
+	"current":       strColumn("current", func(in pricing.InstanceType) string { return toS(in.CurrentGen) }),
+	"family-year":   strColumn("family-year", func(in pricing.InstanceType) string { return toS(familyInfo(in.Family).Year) }),
+	"family-prefix": strColumn("family-prefix", func(in pricing.InstanceType) string { return familyInfo(in.Family).Prefix.String() }),
+	"family-flags":  strColumn("family-flags", func(in pricing.InstanceType) string { return familyInfo(in.Family).Flags.String() }),
+}
+
+// compareRows returns the rows compare lists for instances: every
+// attribute, then every price term any of them has, then with -provenance
+// where the prices were read from.
+func compareRows(instances []pricing.InstanceType) []column {
+	var rows []column
+	for _, name := range []string{
+		"vcpu", "mem", "mem/vcpu", "disk", "disk-gb", "net", "net-gbps",
+		"processor", "clock-speed", "arch", "mfg", "current",
+		"family", "family-year", "family-prefix", "family-flags",
+		"hourly", "monthly", "annual", "3yr",
+	} {
+		c, ok := namedColumns[name]
+		if !ok {
+			c = compareColumns[name]
+		}
+		rows = append(rows, c)
+	}
+
+	for _, term := range pricing.AllRITerms {
+		if !anyInstance(instances, func(in pricing.InstanceType) bool { _, ok := in.ReservedPrice(term); return ok }) {
+			continue
+		}
+		for _, value := range []string{"upfront", "hourly", "annual"} {
+			c, err := reservedColumn(term, value)
+			checkErr(err, "compare")
+			rows = append(rows, c)
+		}
+	}
+	for _, term := range pricing.AllSPTerms {
+		if anyInstance(instances, func(in pricing.InstanceType) bool { _, ok := in.SavingsPlanPrice(term); return ok }) {
+			rows = append(rows, savingsPlanColumn(term))
+		}
+	}
+	if anyInstance(instances, func(in pricing.InstanceType) bool { return in.Spot != nil }) {
+		rows = append(rows, spotColumns()...)
+	}
+	if *provenance {
+		for _, name := range provenanceColumns {
+			rows = append(rows, namedColumns[name])
+		}
+	}
+	return rows
+}
+
+func anyInstance(instances []pricing.InstanceType, f func(in pricing.InstanceType) bool) bool {
+	for _, in := range instances {
+		if f(in) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnNumber returns the value of a numeric column for in, or NaN if it
+// is not a number.
+func columnNumber(c column, in pricing.InstanceType) float64 {
+	if !c.numeric {
+		return math.NaN()
+	}
+	switch v := c.json(in).(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return math.NaN()
+}
+
+// compareTable returns a table with a row for each attribute and a column
+// for each instance type, each after the first followed by its change
+// from the first.
+func compareTable(instances []pricing.InstanceType, csv bool) *table {
+	t := &table{cols: []column{{name: "attribute"}}}
+	for i, in := range instances {
+		t.cols = append(t.cols, column{name: in.Name})
+		if i > 0 {
+			t.cols = append(t.cols, column{name: "Δ%", numeric: true})
+		}
+	}
+
+	for _, c := range compareRows(instances) {
+		row := []string{c.name}
+		base := columnNumber(c, instances[0])
+		for i, in := range instances {
+			if csv {
+				row = append(row, c.csvText(in))
+			} else {
+				row = append(row, c.text(in))
+			}
+			if i == 0 {
+				continue
+			}
+			delta := "-"
+			if v := columnNumber(c, in); base != 0 && !math.IsNaN(base) && !math.IsNaN(v) {
+				delta = fmt.Sprintf("%+.1f", (v-base)/base*100)
+			}
+			row = append(row, delta)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// compareTypes implements the compare command, which lists the attributes
+// and prices of instance types side by side.
+func compareTypes(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] compare type type...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *priceFile == "" && len(regionList()) != 1 {
+		log.Fatal("compare needs a single -region")
+	}
+	render := tableFormats[*outFormat]
+	if render == nil {
+		log.Fatalf("compare cannot write -format %s", *outFormat)
+	}
+
+	opts := loadOptions()
+	opts.Types = fs.Args()
+	loaded := loadInstances(opts)
+
+	var instances []pricing.InstanceType
+	for _, name := range fs.Args() {
+		found := false
+		for _, in := range loaded {
+			if in.Name == name {
+				instances = append(instances, in)
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Fatalf("no price for %s", name)
+		}
+	}
+
+	checkErr(render(os.Stdout, compareTable(instances, *outFormat == "csv")), "Write "+*outFormat)
+}
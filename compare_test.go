@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestCompareTable(t *testing.T) {
+	instances := []pricing.InstanceType{
+		{
+			Name: "m5.large", Family: "m5", VCPU: 2, Memory: 8, Hourly: 0.096,
+			Reserved: []pricing.ReservedPrice{{Term: pricing.DefaultRITerm, Hourly: 0.06, EffectiveAnnual: 525.6}},
+		},
+		{
+			Name: "m7g.large", Family: "m7g", VCPU: 2, Memory: 8, Hourly: 0.0816, CPUMfgr: pricing.CPUAWS,
+			Attributes: pricing.ProductAttributes{PhysicalProcessor: "AWS Graviton3"},
+		},
+	}
+	tab := compareTable(instances, false)
+
+	var header []string
+	for _, c := range tab.cols {
+		header = append(header, c.name)
+	}
+	if exp := []string{"attribute", "m5.large", "m7g.large", "Δ%"}; !reflect.DeepEqual(header, exp) {
+		t.Errorf("header = %q, want %q", header, exp)
+	}
+
+	rows := make(map[string][]string)
+	for _, row := range tab.rows {
+		rows[row[0]] = row[1:]
+	}
+	term := pricing.DefaultRITerm.String()
+	for name, exp := range map[string][]string{
+		"vcpu":               {"2", "2", "+0.0"},
+		"hourly":             {"0.0960", "0.0816", "-15.0"},
+		"processor":          {"", "AWS Graviton3", "-"},
+		"arch":               {"x86_64", "arm64", "-"},
+		"family-year":        {"2017", "2023", "-"},
+		term + "-annual":     {"525.60", "-", "-"},
+		"3yr-std-all-annual": nil,
+	} {
+		if got := rows[name]; !reflect.DeepEqual(got, exp) {
+			t.Errorf("%s = %q, want %q", name, got, exp)
+		}
+	}
+}
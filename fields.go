@@ -0,0 +1,145 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+type fieldKind int
+
+const (
+	numField fieldKind = iota
+	strField
+	boolField
+)
+
+func (k fieldKind) String() string {
+	switch k {
+	case numField:
+		return "number"
+	case strField:
+		return "string"
+	}
+	return "bool"
+}
+
+// A field is a named attribute of an instance type that can be filtered on.
+// Exactly one of num, str and bool is set, according to kind. Missing prices
+// are NaN.
+type field struct {
+	kind fieldKind
+	num  func(in pricing.InstanceType) float64
+	str  func(in pricing.InstanceType) string
+	bool func(in pricing.InstanceType) bool
+	help string
+
+	// cost and setup are those of the column a field is looked up from.
+	cost  bool
+	setup func(opts *pricing.Options)
+}
+
+func numF(help string, f func(in pricing.InstanceType) float64) field {
+	return field{kind: numField, num: f, help: help}
+}
+
+func strF(help string, f func(in pricing.InstanceType) string) field {
+	return field{kind: strField, str: f, help: help}
+}
+
+func boolF(help string, f func(in pricing.InstanceType) bool) field {
+	return field{kind: boolField, bool: f, help: help}
+}
+
+// fields are the instance type attributes known to -where.
+var fields = map[string]field{
+	"name":     strF("instance type, e.g. m5.large", func(in pricing.InstanceType) string { return in.Name }),
+	"type":     strF("alias for name", func(in pricing.InstanceType) string { return in.Name }),
+	"family":   strF("e.g. m5", func(in pricing.InstanceType) string { return in.Family }),
+	"region":   strF("region code", func(in pricing.InstanceType) string { return in.Region }),
+	"os":       strF("operatingSystem attribute, e.g. Linux", func(in pricing.InstanceType) string { return in.OperatingSystem }),
+	"software": strF("preInstalledSw attribute, e.g. NA", func(in pricing.InstanceType) string { return in.PreInstalledSW }),
+	"tenancy":  strF("Shared, Dedicated or Host", func(in pricing.InstanceType) string { return in.Tenancy }),
+	"capacity": strF("capacitystatus attribute", func(in pricing.InstanceType) string { return in.CapacityStatus }),
+	"mfg":      strF("CPU manufacturer: int, amd or arm", func(in pricing.InstanceType) string { return in.CPUMfgr.String() }),
+	"current":  boolF("current generation", func(in pricing.InstanceType) bool { return in.CurrentGen }),
+
+	"vcpu": numF("vCPUs", func(in pricing.InstanceType) float64 { return float64(in.VCPU) }),
+	"mem":  numF("memory in GiB", func(in pricing.InstanceType) float64 { return in.Memory }),
+
+	"disk":       numF("total instance storage in GB", func(in pricing.InstanceType) float64 { return float64(in.Disk.Count * in.Disk.PerDiskGB) }),
+	"disk.count": numF("instance store volumes", func(in pricing.InstanceType) float64 { return float64(in.Disk.Count) }),
+	"disk.size":  numF("GB per instance store volume", func(in pricing.InstanceType) float64 { return float64(in.Disk.PerDiskGB) }),
+	"disk.ebs":   boolF("EBS only", func(in pricing.InstanceType) bool { return in.Disk.Count == 0 }),
+	"disk.ssd":   boolF("SSD instance storage (including NVMe)", func(in pricing.InstanceType) bool { return in.Disk.SSD }),
+	"disk.nvme":  boolF("NVMe instance storage", func(in pricing.InstanceType) bool { return in.Disk.NVMe }),
+
+	"net":       numF("network bandwidth in Gbps", func(in pricing.InstanceType) float64 { return in.NetworkPerf.CapGb }),
+	"net.burst": boolF("network bandwidth is burstable", func(in pricing.InstanceType) bool { return in.NetworkPerf.Bursting }),
+
+	"hourly":   numF("on-demand hourly price", func(in pricing.InstanceType) float64 { return in.Hourly }),
+	"annual":   numF("on-demand annual price", func(in pricing.InstanceType) float64 { return in.OnDemandAnnual }),
+	"reserved": numF("effective annual price under the default reserved term", func(in pricing.InstanceType) float64 { return price(in.ReservedAnnual) }),
+	"spot": numF("spot price (-spot-history and -spot-value)", func(in pricing.InstanceType) float64 {
+		if in.Spot == nil {
+			return math.NaN()
+		}
+		return spotPrice(in.Spot)
+	}),
+}
+
+// lookupField returns the field called name: one of fields, or else the
+// column -columns would list for name, so that -columns, -sort, -where and
+// -pareto-dims all accept the same names.
+func lookupField(name string) (field, bool) {
+	if f, ok := fields[name]; ok {
+		return f, true
+	}
+	c, err := columnByName(name)
+	if err != nil {
+		return field{}, false
+	}
+	f := strF("column", c.text)
+	if c.numeric {
+		f = numF("column", func(in pricing.InstanceType) float64 { return columnNumber(c, in) })
+	}
+	f.cost = c.cost
+	f.setup = c.setup
+	return f, true
+}
+
+// setupFields adjusts the load options for the fields fs, as selectColumns
+// does for columns.
+func setupFields(opts *pricing.Options, fs ...field) {
+	for _, f := range fs {
+		if f.setup != nil {
+			f.setup(opts)
+		}
+	}
+}
+
+// priceOK reports whether p is a price. Zero is the value of a price
+// missing from the price list, so a type that is not offered is never the
+// cheapest.
+func priceOK(p float64) bool {
+	return p != 0
+}
+
+// price returns p, or NaN if it is not a price.
+func price(p float64) float64 {
+	if !priceOK(p) {
+		return math.NaN()
+	}
+	return p
+}
+
+// sortedFieldNames returns the names of fields, sorted.
+func sortedFieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -8,8 +8,10 @@
 //	{"name":"c5d","year":2018,"prefix":"CpuPrefix","flags":["NVMeSuffix"],"url":"https://..."}
 //
 // "prefix" must be one of the InstanceCodePrefix constants and each entry in
-// "flags" one of the InstanceCodeSuffix constants declared in main.go. To add a
-// family, append a line to families.ndjson and run `go generate`.
+// "flags" one of the InstanceCodeSuffix constants declared in main.go. "url"
+// is the family's launch announcement and "notes" is free text, written as a
+// comment. To add a family, append a line to families.ndjson and run `go
+// generate`.
 package main
 
 import (
@@ -31,6 +33,7 @@ type entry struct {
 	Prefix string   `json:"prefix"`
 	Flags  []string `json:"flags,omitempty"`
 	URL    string   `json:"url,omitempty"`
+	Notes  string   `json:"notes,omitempty"`
 }
 
 func main() {
@@ -98,8 +101,8 @@ func run() error {
 	buf.WriteString("var instanceTypes = []InstanceTypeInfo{\n")
 	for _, e := range entries {
 		buf.WriteString("\t{\n")
-		if e.URL != "" {
-			for _, l := range strings.Split(e.URL, "\n") {
+		if e.Notes != "" {
+			for _, l := range strings.Split(e.Notes, "\n") {
 				fmt.Fprintf(&buf, "\t\t// %s\n", l)
 			}
 		}
@@ -109,6 +112,9 @@ func run() error {
 		if len(e.Flags) > 0 {
 			fmt.Fprintf(&buf, "\t\tFlags:  %s,\n", strings.Join(e.Flags, " | "))
 		}
+		if e.URL != "" {
+			fmt.Fprintf(&buf, "\t\tURL:    %q,\n", e.URL)
+		}
 		buf.WriteString("\t},\n")
 	}
 	buf.WriteString("}\n")
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// jsonSchemaVersion is the version of jsonInstance, written as
+// schema_version in every object. It changes when a field is removed or
+// changes meaning; adding a field does not change it.
+const jsonSchemaVersion = 1
+
+// jsonInstance is the documented json and ndjson representation of an
+// instance type. Missing prices are null.
+type jsonInstance struct {
+	SchemaVersion   int     `json:"schema_version"`
+	Type            string  `json:"type"`
+	Family          string  `json:"family"`
+	Region          *string `json:"region"`
+	SKU             string  `json:"sku"`
+	PublicationDate string  `json:"publication_date"`
+
+	OperatingSystem string `json:"operating_system"`
+	Software        string `json:"software"`
+	Tenancy         string `json:"tenancy"`
+	CapacityStatus  string `json:"capacity_status"`
+
+	VCPU              int         `json:"vcpu"`
+	MemoryGiB         float64     `json:"memory_gib"`
+	CPUManufacturer   *string     `json:"cpu_manufacturer"`
+	CurrentGeneration bool        `json:"current_generation"`
+	Storage           jsonStorage `json:"storage"`
+	Network           jsonNetwork `json:"network"`
+
+	OnDemandHourly *float64          `json:"on_demand_hourly"`
+	OnDemandAnnual *float64          `json:"on_demand_annual"`
+	ReservedAnnual *float64          `json:"reserved_annual"`
+	LinuxHourly    *float64          `json:"linux_hourly"`
+	Reserved       []jsonReserved    `json:"reserved"`
+	SavingsPlans   []jsonSavingsPlan `json:"savings_plans"`
+	Spot           *jsonSpot         `json:"spot"`
+	HostFits       []jsonHostFit     `json:"host_fits,omitempty"`
+	Provenance     *jsonProvenance   `json:"provenance,omitempty"`
+	DominatedBy    *string           `json:"dominated_by,omitempty"`
+}
+
+type jsonStorage struct {
+	Type        string `json:"type"` // "ebs", "hdd", "ssd" or "nvme"
+	Volumes     int    `json:"volumes"`
+	VolumeGB    int    `json:"volume_gb"`
+	TotalGB     int    `json:"total_gb"`
+	Description string `json:"description"`
+}
+
+type jsonNetwork struct {
+	Gbps        float64 `json:"gbps"`
+	Burstable   bool    `json:"burstable"`
+	Description string  `json:"description"`
+}
+
+type jsonReserved struct {
+	Term            string           `json:"term"` // e.g. "1yr-conv-none"
+	Length          string           `json:"length"`
+	Class           string           `json:"class"`
+	PurchaseOption  string           `json:"purchase_option"`
+	Upfront         *float64         `json:"upfront"`
+	Hourly          *float64         `json:"hourly"`
+	EffectiveAnnual *float64         `json:"effective_annual"`
+	Source          *jsonPriceSource `json:"source,omitempty"`
+}
+
+type jsonSavingsPlan struct {
+	Term            string   `json:"term"` // e.g. "compute-1yr-none"
+	Type            string   `json:"type"`
+	Length          string   `json:"length"`
+	PurchaseOption  string   `json:"purchase_option"`
+	Hourly          *float64 `json:"hourly"`
+	EffectiveAnnual *float64 `json:"effective_annual"`
+}
+
+type jsonSpot struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Latest float64 `json:"latest"`
+	Zones  int     `json:"zones"`
+}
+
+// jsonProvenance is where the prices of an instance type were read from,
+// with -provenance. The SKU and publication date are always listed.
+type jsonProvenance struct {
+	Version  string          `json:"version"`
+	OnDemand jsonPriceSource `json:"on_demand"`
+}
+
+type jsonPriceSource struct {
+	OfferTermCode   string `json:"offer_term_code"`
+	RateCode        string `json:"rate_code"`
+	UpfrontRateCode string `json:"upfront_rate_code,omitempty"`
+	EffectiveDate   string `json:"effective_date"`
+}
+
+func newJSONPriceSource(src pricing.PriceSource) jsonPriceSource {
+	return jsonPriceSource{
+		OfferTermCode:   src.OfferTermCode,
+		RateCode:        src.RateCode,
+		UpfrontRateCode: src.UpfrontRateCode,
+		EffectiveDate:   src.EffectiveDate,
+	}
+}
+
+type jsonHostFit struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// optPrice returns a pointer to p, or nil if it is not a price.
+func optPrice(p float64) *float64 {
+	if !priceOK(p) {
+		return nil
+	}
+	return &p
+}
+
+// optString returns a pointer to s, or nil if it is empty.
+func optString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// newJSONInstance returns the jsonInstance of in, dominated by the type in
+// doms, if any.
+func newJSONInstance(in pricing.InstanceType, doms dominators) jsonInstance {
+	j := jsonInstance{
+		SchemaVersion:     jsonSchemaVersion,
+		Type:              in.Name,
+		Family:            in.Family,
+		Region:            optString(in.Region),
+		SKU:               in.SKU,
+		PublicationDate:   in.PublicationDate,
+		OperatingSystem:   in.OperatingSystem,
+		Software:          in.PreInstalledSW,
+		Tenancy:           in.Tenancy,
+		CapacityStatus:    in.CapacityStatus,
+		VCPU:              in.VCPU,
+		MemoryGiB:         in.Memory,
+		CurrentGeneration: in.CurrentGen,
+		Storage: jsonStorage{
+			Type:        "ebs",
+			Volumes:     in.Disk.Count,
+			VolumeGB:    in.Disk.PerDiskGB,
+			TotalGB:     in.Disk.Count * in.Disk.PerDiskGB,
+			Description: in.Disk.String(),
+		},
+		Network: jsonNetwork{
+			Gbps:        in.NetworkPerf.CapGb,
+			Burstable:   in.NetworkPerf.Bursting,
+			Description: in.NetworkPerf.String(),
+		},
+		OnDemandHourly: optPrice(in.Hourly),
+		OnDemandAnnual: optPrice(in.OnDemandAnnual),
+		ReservedAnnual: optPrice(in.ReservedAnnual),
+		LinuxHourly:    optPrice(in.LinuxHourly),
+		Reserved:       []jsonReserved{},
+		SavingsPlans:   []jsonSavingsPlan{},
+	}
+
+	if in.CPUMfgr != 0 {
+		j.CPUManufacturer = optString(in.CPUMfgr.String())
+	}
+
+	switch {
+	case in.Disk.Count == 0:
+	case in.Disk.NVMe:
+		j.Storage.Type = "nvme"
+	case in.Disk.SSD:
+		j.Storage.Type = "ssd"
+	default:
+		j.Storage.Type = "hdd"
+	}
+
+	for _, rp := range in.Reserved {
+		jr := jsonReserved{
+			Term:            rp.Term.String(),
+			Length:          rp.Term.Length,
+			Class:           rp.Term.Class,
+			PurchaseOption:  rp.Term.PurchaseOption,
+			Upfront:         &rp.Upfront,
+			Hourly:          &rp.Hourly,
+			EffectiveAnnual: &rp.EffectiveAnnual,
+		}
+		if *provenance {
+			src := newJSONPriceSource(rp.Source)
+			jr.Source = &src
+		}
+		j.Reserved = append(j.Reserved, jr)
+	}
+
+	for _, sp := range in.SavingsPlans {
+		j.SavingsPlans = append(j.SavingsPlans, jsonSavingsPlan{
+			Term:            sp.Term.String(),
+			Type:            sp.Term.Type,
+			Length:          sp.Term.Length,
+			PurchaseOption:  sp.Term.PurchaseOption,
+			Hourly:          &sp.Hourly,
+			EffectiveAnnual: &sp.EffectiveAnnual,
+		})
+	}
+
+	if in.Spot != nil {
+		j.Spot = &jsonSpot{
+			Min:    in.Spot.Min,
+			Median: in.Spot.Median,
+			Latest: in.Spot.Latest,
+			Zones:  in.Spot.Zones,
+		}
+	}
+
+	for _, f := range in.HostFits {
+		j.HostFits = append(j.HostFits, jsonHostFit{Type: f.Type, Count: f.Count})
+	}
+
+	if *provenance {
+		j.Provenance = &jsonProvenance{
+			Version:  in.Version,
+			OnDemand: newJSONPriceSource(in.HourlySource),
+		}
+	}
+	if *paretoAnnotate {
+		j.DominatedBy = optString(doms.dominatedBy(in))
+	}
+
+	return j
+}
+
+// writeJSON writes instances as a JSON array, or as one object per line if
+// ndjson is set. With cols the objects are the columns, in order; without,
+// they are jsonInstances, with the dominators in doms.
+func writeJSON(w io.Writer, cols []column, instances []pricing.InstanceType, doms dominators, ndjson bool) error {
+	objs := make([]json.RawMessage, len(instances))
+	for i, in := range instances {
+		var err error
+		if cols != nil {
+			objs[i], err = columnObject(cols, in)
+		} else {
+			objs[i], err = json.Marshal(newJSONInstance(in, doms))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if ndjson {
+		for _, obj := range objs {
+			if _, err := w.Write(append(obj, '\n')); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	b, err := json.MarshalIndent(objs, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
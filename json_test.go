@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	instances := []pricing.InstanceType{
+		{
+			Name:            "m5d.large",
+			Family:          "m5d",
+			SKU:             "SKU1",
+			PublicationDate: "2026-01-01T00:00:00Z",
+			VCPU:            2,
+			Memory:          8,
+			Disk:            pricing.Disk{Count: 1, PerDiskGB: 75, SSD: true, NVMe: true},
+			Hourly:          0.113,
+			CPUMfgr:         pricing.CPUIntel,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, instances, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("not a JSON array: %s\n%s", err, buf.Bytes())
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d objects, exp 1", len(got))
+	}
+	obj := got[0]
+
+	exp := map[string]interface{}{
+		"schema_version":   float64(jsonSchemaVersion),
+		"type":             "m5d.large",
+		"region":           nil,
+		"sku":              "SKU1",
+		"publication_date": "2026-01-01T00:00:00Z",
+		"cpu_manufacturer": "int",
+		"on_demand_hourly": 0.113,
+		"reserved_annual":  nil,
+		"spot":             nil,
+		"reserved":         []interface{}{},
+		"storage": map[string]interface{}{
+			"type":        "nvme",
+			"volumes":     float64(1),
+			"volume_gb":   float64(75),
+			"total_gb":    float64(75),
+			"description": "75GB-NVMe",
+		},
+	}
+	for k, v := range exp {
+		if !reflect.DeepEqual(obj[k], v) {
+			t.Errorf("%s: got=%#v exp=%#v", k, obj[k], v)
+		}
+	}
+
+	buf.Reset()
+	instances = append(instances, instances[0])
+	if err := writeJSON(&buf, nil, instances, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("ndjson got %d lines, exp 2:\n%s", len(lines), buf.Bytes())
+	}
+	for _, line := range lines {
+		if !json.Valid(line) {
+			t.Errorf("invalid ndjson line: %s", line)
+		}
+	}
+}
+
+func TestWriteJSONProvenance(t *testing.T) {
+	defer func(v bool) { *provenance = v }(*provenance)
+	*provenance = true
+
+	src := pricing.PriceSource{OfferTermCode: "JRTCKXETXF", RateCode: "SKU1.JRTCKXETXF.6YS6EN2CT7", EffectiveDate: "2026-01-01T00:00:00Z"}
+	instances := []pricing.InstanceType{{
+		Name:         "m5.large",
+		SKU:          "SKU1",
+		Version:      "20260101000000",
+		Hourly:       0.096,
+		HourlySource: src,
+		Reserved: []pricing.ReservedPrice{{
+			Term:   pricing.DefaultRITerm,
+			Hourly: 0.07,
+			Source: pricing.PriceSource{OfferTermCode: "7NE97W5U4E", RateCode: "SKU1.7NE97W5U4E.6YS6EN2CT7"},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, instances, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Provenance map[string]interface{} `json:"provenance"`
+		Reserved   []struct {
+			Source map[string]interface{} `json:"source"`
+		} `json:"reserved"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]interface{}{
+		"version": "20260101000000",
+		"on_demand": map[string]interface{}{
+			"offer_term_code": "JRTCKXETXF",
+			"rate_code":       "SKU1.JRTCKXETXF.6YS6EN2CT7",
+			"effective_date":  "2026-01-01T00:00:00Z",
+		},
+	}
+	if !reflect.DeepEqual(got.Provenance, exp) {
+		t.Errorf("provenance: got=%#v exp=%#v", got.Provenance, exp)
+	}
+	if len(got.Reserved) != 1 || got.Reserved[0].Source["rate_code"] != "SKU1.7NE97W5U4E.6YS6EN2CT7" {
+		t.Errorf("reserved source: got=%#v", got.Reserved)
+	}
+}
+
+func TestWriteJSONDominatedBy(t *testing.T) {
+	defer func(v bool) { *paretoAnnotate = v }(*paretoAnnotate)
+	*paretoAnnotate = true
+
+	instances := []pricing.InstanceType{
+		{Name: "m6g.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.077},
+		{Name: "m5.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.096},
+	}
+	dims, err := parseParetoDims("hourly,vcpu,mem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil, instances, findDominators(dims, instances), false); err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got[0]["dominated_by"]; ok {
+		t.Errorf("m6g.large dominated_by=%v, exp absent", got[0]["dominated_by"])
+	}
+	if by := got[1]["dominated_by"]; by != "m6g.large" {
+		t.Errorf("m5.large dominated_by=%v, exp m6g.large", by)
+	}
+	if got[1]["schema_version"] != float64(jsonSchemaVersion) {
+		t.Errorf("schema_version=%v", got[1]["schema_version"])
+	}
+}
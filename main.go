@@ -1,29 +1,52 @@
 package main
 
 import (
-	"encoding/csv"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
-	"regexp"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/psanford/ec2price/pricing"
 )
 
 var (
-	basePriceURL = "https://pricing.us-east-1.amazonaws.com"
-	indexPath    = "/offers/v1.0/aws/index.json"
-
-	region           = flag.String("region", "us-east-1", "AWS Region")
+	region           = flag.String("region", "us-east-1", "AWS Region, comma separated list of regions, or \"all\"")
 	fetchOffers      = flag.Bool("fetch-offers", false, "Fetch offers and price file to disk")
+	offline          = flag.Bool("offline", false, "Read offers and price file from disk instead of fetching them (see -fetch-offers)")
+	offersDir        = flag.String("offers-dir", "/tmp", "Directory -fetch-offers writes to and -offline reads from")
+	cacheDir         = flag.String("cache-dir", pricing.DefaultCacheDir(), "Directory to cache price files in")
+	noCache          = flag.Bool("no-cache", false, "Always download price files, bypassing -cache-dir")
+	priceFile        = flag.String("price-file", "", "Read the region price file from `PATH` instead of fetching it")
+	osName           = flag.String("os", "linux", "Operating system: linux, windows, rhel, rhel-ha, suse, ubuntu-pro, or an operatingSystem attribute value")
+	software         = flag.String("software", "none", "Pre-installed software: none, sql-std, sql-ent or sql-web")
+	license          = flag.String("license", "included", "License model: included or byol")
+	tenancy          = flag.String("tenancy", "shared", "Tenancy: shared, dedicated (Dedicated Instances) or host (Dedicated Hosts, priced per host)")
+	capacityStatus   = flag.String("capacity-status", "used", "Capacity status: used, unused (unused capacity reservations) or allocated (allocated capacity reservations)")
+	osPremium        = flag.Bool("os-premium", false, "Show the hourly premium of -os and -software over plain Linux")
+	riSpec           = flag.String("ri", "", "Comma separated reserved instance terms to add columns for, as LENGTH-CLASS-OPTION (e.g. 3yr-std-all), or \"all\"")
+	riValues         = flag.String("ri-values", "upfront,hourly,annual", "Values to show for each -ri term: upfront, hourly and/or annual (effective, with the upfront fee amortized)")
+	spSpec           = flag.String("sp", "", "Comma separated Savings Plans terms to add hourly rate columns for, as TYPE-LENGTH-OPTION (e.g. compute-1yr-none, ec2-3yr-all), or \"all\"")
+	spotHistory      = flag.String("spot-history", "", "Add spot-price and spot-discount-% columns from `FILE`, the JSON output of aws ec2 describe-spot-price-history")
+	spotValue        = flag.String("spot-value", "latest", "Spot price to show: latest (cheapest zone's most recent), min or median")
 	familyTypes      = flag.Bool("family", false, "Print family type information")
 	checkFamilyTypes = flag.Bool("check-family", false, "Check family types against instance types (for missing types)")
-	outFormat        = flag.String("format", "col", "output format: (col|csv|json")
+	sortSpec         = flag.String("sort", "annual", "Comma separated fields to sort by, each prefixed with - for descending order, e.g. mem,-vcpu,hourly; ties are sorted by name")
+	provenance       = flag.Bool("provenance", false, "Show the SKU, price list version and the term and rate codes each price was read from")
+	columnSpec       = flag.String("columns", "", "Comma separated columns to list, in order (see the Readme for names)")
+	where            = flag.String("where", "", "Only list instance types matching `EXPR`, e.g. 'mem >= 16 && mfg == \"arm\" && disk.nvme'")
+	outFormat        = flag.String("format", "col", "output format: (col|csv|tsv|markdown|html|json|ndjson|matrix|template)")
+	pareto           = flag.Bool("pareto", false, "Only list instance types no other type beats in every -pareto-dims dimension")
+	paretoDims       = flag.String("pareto-dims", "annual,vcpu,mem,disk,net", "Comma separated fields compared by -pareto; prices are better lower, everything else higher")
+	paretoAnnotate   = flag.Bool("pareto-annotate", false, "List every instance type, with the -pareto type that beats it in a dominated-by column")
+	templateText     = flag.String("template", "", "Go text/template for -format template, executed with the list of instance types")
+	templateFile     = flag.String("template-file", "", "Read the -format template template from `PATH`")
 	shortTypes       = flag.Bool("short-type", false, "output using short type names")
 )
 
@@ -42,181 +65,120 @@ func main() {
 		return
 	}
 
-	r, err := http.Get(basePriceURL + indexPath)
-	checkErr(err, "Get index")
-
-	if r.StatusCode != 200 {
-		b, _ := io.ReadAll(r.Body)
-		log.Fatalf("Get index status %d\n%s", r.StatusCode, b)
+	switch flag.Arg(0) {
+	case "":
+	case "regions":
+		listRegions()
+		return
+	case "breakeven":
+		breakEven(flag.Args()[1:])
+		return
+	case "recommend":
+		recommend(flag.Args()[1:])
+		return
+	case "plan":
+		planFleet(flag.Args()[1:])
+		return
+	case "compare":
+		compareTypes(flag.Args()[1:])
+		return
+	case "show":
+		showTypes(flag.Args()[1:])
+		return
+	default:
+		log.Fatalf("unknown command %q", flag.Arg(0))
 	}
 
-	br := teeToFile(r.Body, "/tmp/ec2-price-index.json")
-	dec := json.NewDecoder(br)
-	var idx PriceIndex
-	err = dec.Decode(&idx)
-	checkErr(err, "Read index json")
-	br.Close()
-
-	regionURL := idx.Offers["AmazonEC2"].CurrentRegionIndexURL
+	opts := loadOptions()
 
-	r, err = http.Get(basePriceURL + regionURL)
-	checkErr(err, "Get region index")
-
-	if r.StatusCode != 200 {
-		b, _ := io.ReadAll(r.Body)
-		log.Fatalf("Get region index status %d\n%s", r.StatusCode, b)
+	var tmpl *template.Template
+	switch *outFormat {
+	case "json", "ndjson":
+	case "matrix":
+		if *priceFile != "" {
+			log.Fatal("-format matrix lists prices by region and cannot be combined with -price-file")
+		}
+	case "template":
+		tmpl = parseTemplate()
+	default:
+		if tableFormats[*outFormat] == nil {
+			log.Fatalf("unknown -format %q", *outFormat)
+		}
 	}
 
-	br = teeToFile(r.Body, "/tmp/ec2-price-region-index.json")
-	dec = json.NewDecoder(br)
-	var regionIdx RegionIndex
-	err = dec.Decode(&regionIdx)
-	checkErr(err, "Read region index json")
-	br.Close()
-
-	ec2Path := regionIdx.Regions[*region].CurrentVersionURL
+	multiRegion := len(regionList()) != 1
+	cols := selectColumns(&opts, multiRegion)
 
-	r, err = http.Get(basePriceURL + ec2Path)
-	checkErr(err, "Get prices")
+	keep := mustParseWhere(*where, &opts)
 
-	if r.StatusCode != 200 {
-		b, _ := io.ReadAll(r.Body)
-		log.Fatalf("Get prices status %d\n%s", r.StatusCode, b)
+	sortKeys, err := parseSortKeys(*sortSpec)
+	checkErr(err, "-sort")
+	for _, k := range sortKeys {
+		setupFields(&opts, k.f)
 	}
 
-	br = teeToFile(r.Body, "/tmp/ec2-price.json")
-	dec = json.NewDecoder(br)
-	var prices PriceDoc
-	err = dec.Decode(&prices)
-	checkErr(err, "Read price json")
-	br.Close()
-
-	var instances []InstanceType
-	families := make(map[string]familyInfo)
-
-	for sku, prod := range prices.Products {
-		attrs := prod.Attributes
-
-		if strings.Index(attrs.InstanceType, ".") == -1 ||
-			!strings.HasPrefix(attrs.UsageType, "BoxUsage:") ||
-			attrs.OperatingSystem != "Linux" ||
-			attrs.Operation != "RunInstances" {
-			continue
+	var dims []paretoDim
+	if *pareto || *paretoAnnotate {
+		dims, err = parseParetoDims(*paretoDims)
+		checkErr(err, "-pareto-dims")
+		for _, d := range dims {
+			setupFields(&opts, d.f)
 		}
-
-		var reservedAnnual float64
-		skuTerms := prices.Terms.Reserved[sku]
-	RESERVATION:
-		for _, term := range skuTerms {
-			if term.TermAttributes.LeaseContractLength == "1yr" &&
-				term.TermAttributes.PurchaseOption == "No Upfront" &&
-				term.TermAttributes.OfferingClass == "convertible" {
-				for _, pd := range term.PriceDimensions {
-					if pd.Unit == "Hrs" {
-						f, _ := strconv.ParseFloat(pd.PricePerUnit["USD"], 64)
-						reservedAnnual = f * 24.0 * 365.0
-						break RESERVATION
-					}
-				}
-				break RESERVATION
-			}
-		}
-
-		var onDemandCost float64
-		var hourly float64
-		onDemand := prices.Terms.OnDemand[sku]
-	ONDEMANDOUTER:
-		for _, od := range onDemand {
-			for _, pd := range od.PriceDimensions {
-				f, _ := strconv.ParseFloat(pd.PricePerUnit["USD"], 64)
-				hourly = f
-				onDemandCost = f * 24.0 * 365.0
-				break ONDEMANDOUTER
-			}
+	}
+	if *paretoAnnotate {
+		switch {
+		case *pareto:
+			log.Fatal("-pareto-annotate cannot be combined with -pareto")
+		case *outFormat == "matrix":
+			log.Fatal("-pareto-annotate cannot be combined with -format matrix")
 		}
+	}
 
-		memS := strings.TrimSuffix(attrs.Memory, " GiB")
-		memS = strings.ReplaceAll(memS, ",", "")
-		mem, _ := strconv.ParseFloat(memS, 64)
+	instances := filterWhere(loadInstances(opts), keep)
 
-		disk, err := parseStorage(attrs.Storage)
-		if err != nil {
-			log.Printf("parse storage for %s err: %s", attrs.InstanceType, err)
-		}
+	sortInstances(instances, sortKeys)
 
-		instType := attrs.InstanceType
+	families := make(map[string]bool)
+	for i, in := range instances {
+		families[in.Family] = true
 		if *shortTypes {
-			instType = shortType(instType)
-		}
-
-		np, err := parseNetPerf(attrs.NetworkPerformance)
-		if err != nil {
-			log.Print(err)
-		}
-
-		instance := InstanceType{
-			Name:           instType,
-			VCPU:           attrs.VCPU,
-			Memory:         mem,
-			Disk:           disk,
-			Hourly:         hourly,
-			OnDemandAnnual: onDemandCost,
-			ReservedAnnual: reservedAnnual,
-			CPUMfgr:        mfgrFromString(attrs.PhysicalProcessor),
-			NetworkPerf:    np,
+			instances[i].Name = shortType(in.Name)
 		}
+	}
 
-		family := strings.SplitN(attrs.InstanceType, ".", 2)[0]
-		families[family] = familyInfo{
-			InstanceFamily:    attrs.InstanceFamily,
-			PhysicalProcessor: attrs.PhysicalProcessor,
-			CPUMfgr:           instance.CPUMfgr,
-			CurrentGen:        attrs.CurrentGeneration == "Yes",
+	var doms dominators
+	if dims != nil {
+		doms = findDominators(dims, instances)
+		bindDominators(cols, doms)
+		if *pareto {
+			var front []pricing.InstanceType
+			for _, in := range instances {
+				if doms.dominatedBy(in) == "" {
+					front = append(front, in)
+				}
+			}
+			instances = front
 		}
-
-		instances = append(instances, instance)
 	}
 
-	sort.Slice(instances, func(a, b int) bool { return instances[a].OnDemandAnnual < instances[b].OnDemandAnnual })
-
-	fieldNames := []string{"type", "mem", "vcpu", "disk", "mfg", "net", "hourly", "annual", "annual-reserved"}
-
-	if *outFormat == "csv" {
-		w := csv.NewWriter(os.Stdout)
-		w.Write(fieldNames)
-		for _, in := range instances {
-			w.Write([]string{
-				toS(in.Name),
-				toS(in.Memory),
-				toS(in.VCPU),
-				toS(in.Disk),
-				toS(in.CPUMfgr),
-				toS(in.NetworkPerf),
-				toS(in.Hourly),
-				toS(in.OnDemandAnnual),
-				toS(in.ReservedAnnual),
-			})
-		}
-		w.Flush()
+	switch *outFormat {
+	case "matrix":
+		checkErr(printMatrix(os.Stdout, instances), "Write matrix")
 		return
-	} else if *outFormat == "json" {
-		w := json.NewEncoder(os.Stdout)
-		w.SetIndent("", "  ")
-		for _, in := range instances {
-			w.Encode(in)
+	case "template":
+		checkErr(renderTemplate(os.Stdout, tmpl, instances, doms), "-template")
+		return
+	case "json", "ndjson":
+		var jsonCols []column
+		if *columnSpec != "" {
+			jsonCols = cols
 		}
+		checkErr(writeJSON(os.Stdout, jsonCols, instances, doms, *outFormat == "ndjson"), "Write json")
 		return
 	}
-	format := "%17s %10.01f %6s %15s %3s %6s %9.04f %9.02f %.2f\n"
-	var fieldNamesI []interface{} = make([]interface{}, len(fieldNames))
-	for i, d := range fieldNames {
-		fieldNamesI[i] = d
-	}
-	fmt.Printf("%17s %10s %6s %15s %3s %6s %9s %9s %s\n", fieldNamesI...)
 
-	for _, in := range instances {
-		fmt.Printf(format, in.Name, in.Memory, in.VCPU, in.Disk, in.CPUMfgr, in.NetworkPerf, in.Hourly, in.OnDemandAnnual, in.ReservedAnnual)
-	}
+	t := newTable(cols, instances, *outFormat == "csv")
+	checkErr(tableFormats[*outFormat](os.Stdout, t), "Write "+*outFormat)
 
 	if *checkFamilyTypes {
 
@@ -249,6 +211,7 @@ type InstanceTypeInfo struct {
 	Year   int
 	Prefix InstanceCodePrefix
 	Flags  InstanceCodeSuffix
+	URL    string // launch announcement
 }
 
 func (it InstanceTypeInfo) String() string {
@@ -380,191 +343,236 @@ func (c InstanceCodePrefix) String() string {
 	return fmt.Sprintf("unknown<%x>", int(c))
 }
 
-func teeToFile(rc io.ReadCloser, path string) io.ReadCloser {
-	if !*fetchOffers {
-		return rc
+// loadOptions returns the pricing options selected by the flags.
+func loadOptions() pricing.Options {
+	opts := pricing.Options{
+		OperatingSystem: lookupName(osNames, *osName, "-os"),
+		PreInstalledSW:  lookupName(softwareNames, *software, "-software"),
+		Tenancy:         lookupName(tenancyNames, *tenancy, "-tenancy"),
+		CapacityStatus:  lookupName(capacityStatusNames, *capacityStatus, "-capacity-status"),
+		LinuxPremium:    *osPremium,
+		SavingsPlans:    *spSpec != "",
+		Logf:            log.Printf,
+	}
+	switch *license {
+	case "included":
+	case "byol":
+		opts.BYOL = true
+	default:
+		log.Fatalf("unknown -license %q", *license)
 	}
 
-	f, err := os.Create(path)
-	if err != nil {
-		panic(err)
+	return opts
+}
+
+// loadInstances loads the instance types for -region, or from -price-file.
+func loadInstances(opts pricing.Options) []pricing.InstanceType {
+	if *fetchOffers && (*offline || *priceFile != "") {
+		log.Fatal("-fetch-offers cannot be combined with -offline or -price-file")
+	}
+	if opts.SavingsPlans && *priceFile != "" {
+		log.Fatal("-sp cannot be combined with -price-file")
 	}
 
-	tr := teeReader{
-		Reader: io.TeeReader(rc, f),
-		rc:     rc,
-		f:      f,
-		name:   path,
+	var instances []pricing.InstanceType
+	if *priceFile != "" {
+		f, err := os.Open(*priceFile)
+		checkErr(err, "Open price file")
+		instances, err = pricing.LoadDoc(f, opts)
+		checkErr(err, "Read price json")
+		f.Close()
+	} else {
+		var err error
+		instances, err = pricing.LoadRegions(context.Background(), priceSource(), regionList(), opts)
+		checkErr(err, "Load prices")
 	}
 
-	return &tr
+	if *spotHistory != "" {
+		f, err := os.Open(*spotHistory)
+		checkErr(err, "Open spot history")
+		h, err := pricing.ReadSpotHistory(f)
+		checkErr(err, "Read spot history")
+		f.Close()
+		h.Apply(instances)
+	}
+
+	return instances
 }
 
-type teeReader struct {
-	io.Reader
+// listRegions prints every region in the price list with its location.
+func listRegions() {
+	regions, err := pricing.Regions(context.Background(), priceSource())
+	checkErr(err, "Load regions")
 
-	rc   io.ReadCloser
-	f    *os.File
-	name string
+	for _, r := range regions {
+		fmt.Printf("%-16s %-18s %s\n", r.Code, r.LocationType, r.Location)
+	}
 }
 
-func (tr *teeReader) Close() error {
-	tr.f.Close()
-	fmt.Printf("wrote %s\n", tr.name)
-	return tr.rc.Close()
+func formatHostFits(fits []pricing.HostFit) string {
+	parts := make([]string, len(fits))
+	for i, f := range fits {
+		parts[i] = fmt.Sprintf("%s:%d", f.Type, f.Count)
+	}
+	return strings.Join(parts, " ")
 }
 
-type CPUManufacturer int
+var osNames = map[string]string{
+	"linux":      "Linux",
+	"windows":    "Windows",
+	"rhel":       "RHEL",
+	"rhel-ha":    "Red Hat Enterprise Linux with HA",
+	"suse":       "SUSE",
+	"ubuntu-pro": "Ubuntu Pro",
+}
 
-const (
-	CPUIntel CPUManufacturer = 1
-	CPUAMD   CPUManufacturer = 2
-	CPUAWS   CPUManufacturer = 3
-)
+var tenancyNames = map[string]string{
+	"shared":    pricing.TenancyShared,
+	"dedicated": pricing.TenancyDedicated,
+	"host":      pricing.TenancyHost,
+}
 
-func (c CPUManufacturer) String() string {
-	switch c {
-	case CPUIntel:
-		return "int"
-	case CPUAMD:
-		return "amd"
-	case CPUAWS:
-		return "arm"
-	}
+var capacityStatusNames = map[string]string{
+	"used":      pricing.CapacityUsed,
+	"unused":    pricing.CapacityUnusedReservation,
+	"allocated": pricing.CapacityAllocatedReservation,
+}
 
-	return "unk"
+var softwareNames = map[string]string{
+	"none":    "NA",
+	"sql-std": "SQL Std",
+	"sql-ent": "SQL Ent",
+	"sql-web": "SQL Web",
 }
 
-func mfgrFromString(s string) CPUManufacturer {
-	if strings.Contains(s, "Intel") {
-		return CPUIntel
-	} else if strings.Contains(s, "AMD") {
-		return CPUAMD
-	} else if strings.Contains(s, "AWS") {
-		return CPUAWS
+// lookupName maps a short flag value to its product attribute value. Other
+// values are passed through as attribute values, except all lower case ones,
+// which are assumed to be misspelled short names.
+func lookupName(names map[string]string, v, flagName string) string {
+	if attr, ok := names[strings.ToLower(v)]; ok {
+		return attr
+	}
+	for _, attr := range names {
+		if v == attr {
+			return attr
+		}
+	}
+	if strings.ToLower(v) == v {
+		log.Fatalf("unknown %s %q", flagName, v)
 	}
-	return 0
+	return v
 }
 
-type InstanceType struct {
-	Name           string
-	VCPU           string
-	Memory         float64
-	SSD            bool
-	Disk           Disk
-	Hourly         float64
-	OnDemandAnnual float64
-	ReservedAnnual float64
-	CPUMfgr        CPUManufacturer
-	CurrentGen     string
-	NetworkPerf    NetworkPerf
+// regionList returns the regions named by -region, or nil for all regions.
+func regionList() []string {
+	if *region == "all" {
+		return nil
+	}
+	var regions []string
+	for _, r := range strings.Split(*region, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			regions = append(regions, r)
+		}
+	}
+	return regions
 }
 
-func checkErr(err error, msg string) {
-	if err != nil {
-		log.Fatalf("Error: %s: %s", msg, err)
+// priceSource returns where the flags say to load price files from.
+func priceSource() pricing.Source {
+	var src pricing.Source = pricing.HTTPSource{}
+	if *offline {
+		src = pricing.DirSource{Dir: *offersDir}
+	} else if !*noCache && *cacheDir != "" {
+		src = pricing.CacheSource{Dir: *cacheDir}
 	}
+
+	if *fetchOffers {
+		src = teeSource{src}
+	}
+	return src
 }
 
-type PriceIndex struct {
-	Disclaimer      string           `json:"disclaimer"`
-	FormatVersion   string           `json:"formatVersion"`
-	Offers          map[string]Offer `json:"offers"`
-	PublicationDate string           `json:"publicationDate"`
+// teeSource saves a copy of every document it opens to -offers-dir.
+type teeSource struct {
+	pricing.Source
 }
 
-type Offer struct {
-	CurrentRegionIndexURL string `json:"currentRegionIndexUrl"`
-	CurrentVersionURL     string `json:"currentVersionUrl"`
-	OfferCode             string `json:"offerCode"`
-	VersionIndexURL       string `json:"versionIndexUrl"`
+func (s teeSource) Open(ctx context.Context, ref pricing.DocRef) (io.ReadCloser, error) {
+	rc, err := s.Source.Open(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return teeToFile(rc, filepath.Join(*offersDir, ref.LocalName())), nil
 }
 
-type RegionIndex struct {
-	Disclaimer      string `json:"disclaimer"`
-	FormatVersion   string `json:"formatVersion"`
-	PublicationDate string `json:"publicationDate"`
-	Regions         map[string]struct {
-		CurrentVersionURL string `json:"currentVersionUrl"`
-		RegionCode        string `json:"regionCode"`
-	} `json:"regions"`
+// teeToFile returns a reader of rc that saves what is read to path. The copy
+// only replaces path if rc was read to the end, so a document abandoned part
+// way, as pricing.Regions does, is not saved.
+func teeToFile(rc io.ReadCloser, path string) io.ReadCloser {
+	if !*fetchOffers {
+		return rc
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		panic(err)
+	}
+
+	tr := teeReader{
+		Reader: io.TeeReader(rc, f),
+		rc:     rc,
+		f:      f,
+		name:   path,
+	}
+
+	return &tr
 }
 
-type PriceDoc struct {
-	Disclaimer      string             `json:"disclaimer"`
-	FormatVersion   string             `json:"formatVersion"`
-	PublicationDate string             `json:"publicationDate"`
-	OfferCode       string             `json:"offerCode"`
-	Products        map[string]Product `json:"products"`
-	Terms           struct {
-		OnDemand map[string]map[string]Term `json:"OnDemand"`
-		Reserved map[string]map[string]Term `json:"Reserved"`
-	} `json:"terms"`
-	Version string `json:"version"`
+type teeReader struct {
+	io.Reader
+
+	rc   io.ReadCloser
+	f    *os.File
+	name string
+	eof  bool
+	err  error
 }
 
-type Term struct {
-	EffectiveDate   string `json:"effectiveDate"`
-	OfferTermCode   string `json:"offerTermCode"`
-	PriceDimensions map[string]struct {
-		AppliesTo    []interface{}     `json:"appliesTo"`
-		BeginRange   string            `json:"beginRange"`
-		Description  string            `json:"description"`
-		EndRange     string            `json:"endRange"`
-		PricePerUnit map[string]string `json:"pricePerUnit"`
-		RateCode     string            `json:"rateCode"`
-		Unit         string            `json:"unit"`
-	} `json:"priceDimensions"`
-	Sku            string `json:"sku"`
-	TermAttributes struct {
-		LeaseContractLength string `json:"LeaseContractLength"`
-		OfferingClass       string `json:"OfferingClass"`
-		PurchaseOption      string `json:"PurchaseOption"`
-	} `json:"termAttributes"`
+func (tr *teeReader) Read(p []byte) (int, error) {
+	n, err := tr.Reader.Read(p)
+	if err == io.EOF {
+		tr.eof = true
+	} else if err != nil {
+		tr.err = err
+	}
+	return n, err
 }
 
-type Product struct {
-	Attributes struct {
-		CapacityStatus              string `json:"capacitystatus"`
-		ClockSpeed                  string `json:"clockSpeed"`
-		CurrentGeneration           string `json:"currentGeneration"`
-		DedicatedEBSThroughput      string `json:"dedicatedEbsThroughput"`
-		ECU                         string `json:"ecu"`
-		EnhancedNetworkingSupported string `json:"enhancedNetworkingSupported"`
-		GPU                         string `json:"gpu"`
-		InstanceFamily              string `json:"instanceFamily"`
-		InstanceType                string `json:"instanceType"`
-		IntelAVX2Available          string `json:"intelAvx2Available"`
-		IntelAVXAvailable           string `json:"intelAvxAvailable"`
-		IntelTurboAvailable         string `json:"intelTurboAvailable"`
-		LicenseModel                string `json:"licenseModel"`
-		Location                    string `json:"location"`
-		LocationType                string `json:"locationType"`
-		Memory                      string `json:"memory"`
-		NetworkPerformance          string `json:"networkPerformance"`
-		NormalizationSizeFactor     string `json:"normalizationSizeFactor"`
-		OperatingSystem             string `json:"operatingSystem"`
-		Operation                   string `json:"operation"`
-		PhysicalProcessor           string `json:"physicalProcessor"`
-		PreInstalledSW              string `json:"preInstalledSw"`
-		ProcessorArchitecture       string `json:"processorArchitecture"`
-		ProcessorFeatures           string `json:"processorFeatures"`
-		ServiceCode                 string `json:"servicecode"`
-		ServiceName                 string `json:"servicename"`
-		Storage                     string `json:"storage"`
-		Tenancy                     string `json:"tenancy"`
-		UsageType                   string `json:"usagetype"`
-		VCPU                        string `json:"vcpu"`
-	} `json:"attributes"`
-	ProductFamily string `json:"productFamily"`
-	Sku           string `json:"sku"`
+func (tr *teeReader) Close() error {
+	// JSON decoders stop at the end of the value, which usually leaves a
+	// trailing newline unread.
+	if !tr.eof && tr.err == nil {
+		io.CopyN(io.Discard, tr, 4096)
+	}
+	err := tr.rc.Close()
+
+	tmpName := tr.f.Name()
+	if ferr := tr.f.Close(); ferr != nil || !tr.eof {
+		os.Remove(tmpName)
+		return err
+	}
+	if rerr := os.Rename(tmpName, tr.name); rerr != nil {
+		os.Remove(tmpName)
+		return rerr
+	}
+	fmt.Printf("wrote %s\n", tr.name)
+	return err
 }
 
-type familyInfo struct {
-	InstanceFamily    string
-	PhysicalProcessor string
-	CPUMfgr           CPUManufacturer
-	CurrentGen        bool
+func checkErr(err error, msg string) {
+	if err != nil {
+		log.Fatalf("Error: %s: %s", msg, err)
+	}
 }
 
 func toS(i interface{}) string {
@@ -598,137 +606,3 @@ var typeReplacer = strings.NewReplacer(
 func shortType(fullType string) string {
 	return typeReplacer.Replace(fullType)
 }
-
-type NetworkPerf struct {
-	CapGb    float64
-	Bursting bool
-}
-
-func (np NetworkPerf) String() string {
-	var burstIndicator string
-
-	if np.Bursting {
-		burstIndicator = "*"
-	}
-	return fmt.Sprintf("%0.1f%s", np.CapGb, burstIndicator)
-}
-
-var netPerfRE = regexp.MustCompile(`(Up to )?(\d+) (Gigabit|Megabit)`)
-
-func parseNetPerf(n string) (NetworkPerf, error) {
-	var perf NetworkPerf
-
-	m := netPerfRE.FindStringSubmatch(n)
-	if len(m) > 0 {
-
-		nStr := m[2]
-		f, _ := strconv.ParseFloat(nStr, 64)
-		if m[3] == "Megabit" {
-			f = f / 1000
-		}
-
-		perf.CapGb = f
-		if m[1] != "" {
-			perf.Bursting = true
-		}
-
-		return perf, nil
-	}
-
-	words := map[string]NetworkPerf{
-		"Very Low": {
-			CapGb:    0.01,
-			Bursting: true,
-		},
-		"High": {
-			CapGb: 1,
-		},
-		"Low": {
-			CapGb:    0.01,
-			Bursting: true,
-		},
-		"Low to Moderate": {
-			CapGb:    0.01,
-			Bursting: true,
-		},
-		"Moderate": {
-			CapGb:    0.1,
-			Bursting: true,
-		},
-		"NA": {
-			CapGb: 1,
-		},
-	}
-
-	if match, found := words[n]; found {
-		return match, nil
-	}
-
-	return NetworkPerf{}, fmt.Errorf("failed to parse network perf: %q", n)
-}
-
-type Disk struct {
-	Count     int // count 0 means EBSOnly
-	PerDiskGB int
-	SSD       bool
-	NVMe      bool
-}
-
-func (d Disk) String() string {
-	if d.Count == 0 {
-		return "EBS"
-	}
-	suffix := "GB"
-	total := d.Count * d.PerDiskGB
-	if total > 1000*1000 {
-		suffix = "PB"
-		total /= 1000 * 1000
-	} else if total > 1000 {
-		suffix = "TB"
-		total /= 1000
-	}
-
-	typ := "HDD"
-	if d.NVMe {
-		typ = "NVMe"
-	} else if d.SSD {
-		typ = "SSD"
-	}
-
-	return fmt.Sprintf("%d%s-%s", total, suffix, typ)
-}
-
-var diskRE = regexp.MustCompile(`(?i)(?:(\d+) x )?(\d+)(?:GB| GB)?( NVMe)?(?: (SSD|HDD))?`)
-
-func parseStorage(s string) (Disk, error) {
-	var d Disk
-	if s == "EBS only" {
-		return d, nil
-	}
-
-	m := diskRE.FindStringSubmatch(s)
-
-	if len(m) < 1 {
-		return d, fmt.Errorf("parse storage fail for %q", s)
-	}
-
-	d.Count = 1
-
-	if m[1] != "" {
-		d.Count, _ = strconv.Atoi(m[1])
-	}
-
-	d.PerDiskGB, _ = strconv.Atoi(m[2])
-
-	if m[3] != "" {
-		d.NVMe = true
-		d.SSD = true
-	}
-
-	// If type is specified as SSD or if GB is present without type (i8g's do this)
-	if m[4] == "SSD" || (m[4] == "" && strings.Contains(s, "GB")) {
-		d.SSD = true
-	}
-
-	return d, nil
-}
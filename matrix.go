@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// matrixTable returns the hourly price of each instance type in each region
+// loaded, one row per type, with the cheapest region for each type marked
+// with a *. Regions are in -region order, or sorted for -region all, and
+// rows are in the order each type first appears in instances.
+func matrixTable(instances []pricing.InstanceType) *table {
+	loaded := make(map[string]bool)
+	for _, in := range instances {
+		loaded[in.Region] = true
+	}
+	var regions []string
+	for _, region := range regionList() {
+		if loaded[region] {
+			regions = append(regions, region)
+			delete(loaded, region)
+		}
+	}
+	var rest []string
+	for region := range loaded {
+		rest = append(rest, region)
+	}
+	sort.Strings(rest)
+	regions = append(regions, rest...)
+
+	var names []string
+	prices := make(map[string]map[string]float64)
+	for _, in := range instances {
+		if prices[in.Name] == nil {
+			prices[in.Name] = make(map[string]float64)
+			names = append(names, in.Name)
+		}
+		prices[in.Name][in.Region] = in.Hourly
+	}
+
+	t := &table{cols: []column{{name: "type"}}}
+	for _, region := range regions {
+		t.cols = append(t.cols, column{name: region, numeric: true})
+	}
+
+	for _, name := range names {
+		cheapest := ""
+		for _, region := range regions {
+			p, ok := prices[name][region]
+			if ok && p > 0 && (cheapest == "" || p < prices[name][cheapest]) {
+				cheapest = region
+			}
+		}
+
+		row := []string{name}
+		for _, region := range regions {
+			p, ok := prices[name][region]
+			switch {
+			case !ok:
+				row = append(row, "-")
+			case region == cheapest:
+				row = append(row, fmt.Sprintf("%.4f*", p))
+			default:
+				row = append(row, fmt.Sprintf("%.4f", p))
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// printMatrix writes the matrixTable of instances, followed with
+// -provenance by the price list each region was read from.
+func printMatrix(w io.Writer, instances []pricing.InstanceType) error {
+	t := matrixTable(instances)
+	if err := renderCol(w, t); err != nil {
+		return err
+	}
+	if !*provenance {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	for _, c := range t.cols[1:] {
+		for _, in := range instances {
+			if in.Region == c.name {
+				if _, err := fmt.Fprintf(w, "%-15s version %s published %s\n", c.name, in.Version, in.PublicationDate); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestMatrix(t *testing.T) {
+	defer func(v string) { *region = v }(*region)
+	*region = "us-east-1,eu-west-1,ap-south-1"
+
+	instances := []pricing.InstanceType{
+		{Name: "u7in-24tb.224xlarge", Region: "us-east-1", Hourly: 292.24},
+		{Name: "m5.large", Region: "us-east-1", Hourly: 0.096},
+		{Name: "m5.large", Region: "eu-west-1", Hourly: 0.107},
+	}
+
+	var buf bytes.Buffer
+	if err := printMatrix(&buf, instances); err != nil {
+		t.Fatal(err)
+	}
+	exp := `
+               type us-east-1 eu-west-1
+u7in-24tb.224xlarge 292.2400*         -
+           m5.large   0.0960*    0.1070
+`
+	if got := buf.String(); got != strings.TrimPrefix(exp, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", got, exp)
+	}
+}
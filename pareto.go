@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A paretoDim is one dimension of -pareto. Prices are better lower, every
+// other field higher.
+type paretoDim struct {
+	name  string
+	f     field
+	lower bool
+}
+
+// parseParetoDims parses a comma separated list of numeric field names.
+func parseParetoDims(spec string) ([]paretoDim, error) {
+	var dims []paretoDim
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		f, ok := lookupField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (fields: %s, or a column)", name, strings.Join(sortedFieldNames(), ", "))
+		}
+		if f.kind != numField {
+			return nil, fmt.Errorf("%s is a %s, not a number", name, f.kind)
+		}
+		dims = append(dims, paretoDim{name: name, f: f, lower: f.cost || slices.Contains(priceFields, name)})
+	}
+	return dims, nil
+}
+
+// better returns 1 if a is better than b in d, -1 if worse and 0 if they
+// are equal. A missing value, including a zero price, is worse than any
+// other.
+func (d paretoDim) better(a, b pricing.InstanceType) int {
+	x, y := d.f.num(a), d.f.num(b)
+	if d.lower {
+		x, y = price(x), price(y)
+	}
+	switch {
+	case math.IsNaN(x) && math.IsNaN(y), x == y:
+		return 0
+	case math.IsNaN(x):
+		return -1
+	case math.IsNaN(y):
+		return 1
+	case (x < y) == d.lower:
+		return 1
+	}
+	return -1
+}
+
+// dominates reports whether a is at least as good as b in every dimension
+// and better in at least one.
+func dominates(dims []paretoDim, a, b pricing.InstanceType) bool {
+	strictly := false
+	for _, d := range dims {
+		switch d.better(a, b) {
+		case -1:
+			return false
+		case 1:
+			strictly = true
+		}
+	}
+	return strictly
+}
+
+// paretoKey identifies an instance type in dominators.
+func paretoKey(in pricing.InstanceType) string {
+	return in.Region + " " + in.Name
+}
+
+// dominators maps the paretoKey of each dominated instance type to the name
+// of the type that dominates it.
+type dominators map[string]string
+
+// dominatedBy returns the name of the type dominating in, or "" if none
+// does.
+func (d dominators) dominatedBy(in pricing.InstanceType) string {
+	return d[paretoKey(in)]
+}
+
+// findDominators returns the paretoKey of each instance type dominated by
+// another in the same region, mapped to the name of the dominating type
+// that is best in the first dimension.
+func findDominators(dims []paretoDim, instances []pricing.InstanceType) dominators {
+	m := make(dominators)
+	for _, b := range instances {
+		var best *pricing.InstanceType
+		for i, a := range instances {
+			if a.Region != b.Region || !dominates(dims, a, b) {
+				continue
+			}
+			if best == nil || dims[0].better(a, *best) > 0 || (dims[0].better(a, *best) == 0 && a.Name < best.Name) {
+				best = &instances[i]
+			}
+		}
+		if best != nil {
+			m[paretoKey(b)] = best.Name
+		}
+	}
+	return m
+}
+
+// paretoColumns returns the columns for -pareto-annotate. The types are
+// found after loading, so the column is empty until bindDominators.
+func paretoColumns() []column {
+	if !*paretoAnnotate {
+		return nil
+	}
+	return []column{dominatedByColumn(nil)}
+}
+
+// dominatedByColumn returns the dominated-by column, listing the type in d
+// that dominates each instance type.
+func dominatedByColumn(d dominators) column {
+	return column{
+		name: "dominated-by",
+		text: func(in pricing.InstanceType) string {
+			if by := d.dominatedBy(in); by != "" {
+				return by
+			}
+			return "-"
+		},
+		json: func(in pricing.InstanceType) interface{} {
+			if by := d.dominatedBy(in); by != "" {
+				return by
+			}
+			return nil
+		},
+	}
+}
+
+// bindDominators points the dominated-by columns in cols at d.
+func bindDominators(cols []column, d dominators) {
+	for i, c := range cols {
+		if c.name == "dominated-by" {
+			cols[i] = dominatedByColumn(d)
+		}
+	}
+}
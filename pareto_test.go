@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestFindDominators(t *testing.T) {
+	dims, err := parseParetoDims("hourly,vcpu,mem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	instances := []pricing.InstanceType{
+		{Name: "m5.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.096},
+		{Name: "m7g.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.0816},
+		{Name: "m6g.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.077},
+		{Name: "r5.large", Region: "us-east-1", VCPU: 2, Memory: 16, Hourly: 0.126},
+		{Name: "t3.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.077},
+		{Name: "unpriced.large", Region: "us-east-1", VCPU: 2, Memory: 4},
+		{Name: "m5.large", Region: "eu-west-1", VCPU: 2, Memory: 8, Hourly: 0.107},
+	}
+	exp := dominators{
+		"us-east-1 m5.large":       "m6g.large",
+		"us-east-1 m7g.large":      "m6g.large",
+		"us-east-1 unpriced.large": "m6g.large",
+	}
+	doms := findDominators(dims, instances)
+	if !reflect.DeepEqual(doms, exp) {
+		t.Errorf("got %v, want %v", doms, exp)
+	}
+
+	cols := []column{namedColumns["type"], dominatedByColumn(nil)}
+	bindDominators(cols, doms)
+	for i, exp := range []string{"m6g.large", "m6g.large", "-", "-", "-", "m6g.large", "-"} {
+		if got := cols[1].text(instances[i]); got != exp {
+			t.Errorf("%s %s dominated-by = %q, want %q", instances[i].Region, instances[i].Name, got, exp)
+		}
+	}
+
+	for _, spec := range []string{"hourly,nope", "hourly,family", "current"} {
+		if _, err := parseParetoDims(spec); err == nil {
+			t.Errorf("parseParetoDims(%q) = nil error", spec)
+		}
+	}
+}
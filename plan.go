@@ -0,0 +1,432 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A workload is the plan command's description of what a fleet has to run.
+type workload struct {
+	Pods []podGroup `json:"pods"`
+
+	// NodeOverhead is reserved on every node for the kubelet, system
+	// daemons and the like, and is not available to pods.
+	NodeOverhead struct {
+		VCPU      float64 `json:"vcpu"`
+		MemoryGiB float64 `json:"memory_gib"`
+	} `json:"node_overhead"`
+}
+
+// A podGroup is Count identical pods.
+type podGroup struct {
+	Name      string  `json:"name"`
+	Count     int     `json:"count"`
+	VCPU      float64 `json:"vcpu"`
+	MemoryGiB float64 `json:"memory_gib"`
+
+	// MaxPerNode limits how many of the group's pods share a node, as
+	// anti-affinity does; 1 puts every pod on its own node. 0 is no limit.
+	MaxPerNode int `json:"max_per_node"`
+}
+
+func readWorkload(r io.Reader) (workload, error) {
+	var w workload
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&w); err != nil {
+		return w, err
+	}
+
+	if len(w.Pods) == 0 {
+		return w, fmt.Errorf("no pods")
+	}
+	if w.NodeOverhead.VCPU < 0 || w.NodeOverhead.MemoryGiB < 0 {
+		return w, fmt.Errorf("negative node_overhead")
+	}
+	for i, g := range w.Pods {
+		if g.Name == "" {
+			w.Pods[i].Name = fmt.Sprintf("pods[%d]", i)
+		}
+		if g.Count < 0 || g.VCPU < 0 || g.MemoryGiB < 0 || g.MaxPerNode < 0 {
+			return w, fmt.Errorf("%s: negative count, vcpu, memory_gib or max_per_node", w.Pods[i].Name)
+		}
+		if g.VCPU == 0 && g.MemoryGiB == 0 {
+			return w, fmt.Errorf("%s: pods need vcpu or memory_gib", w.Pods[i].Name)
+		}
+	}
+	return w, nil
+}
+
+// A node is one instance in a fleet and the pods placed on it.
+type node struct {
+	in        *pricing.InstanceType
+	vcpu, mem float64 // used by pods
+	groups    []int   // pods of each group, by index into workload.Pods
+}
+
+// A fleet is a set of nodes running a workload.
+type fleet []*node
+
+func (f fleet) hourly() float64 {
+	var total float64
+	for _, n := range f {
+		total += n.in.Hourly
+	}
+	return total
+}
+
+// planner packs a workload's pods onto nodes. Pods of a group are identical,
+// so they are placed by the group, as many at a time as fit.
+type planner struct {
+	w      workload
+	types  []pricing.InstanceType // cheapest first, none dominated
+	groups []int                  // indexes into w.Pods, largest pods first
+
+	totalVCPU, totalMem float64 // of all pods
+}
+
+func newPlanner(w workload, types []pricing.InstanceType) *planner {
+	p := &planner{w: w}
+	var usable []pricing.InstanceType
+	for _, in := range types {
+		if in.Hourly > 0 && p.capVCPU(&in) > 0 && p.capMem(&in) > 0 {
+			usable = append(usable, in)
+		}
+	}
+	// Of types with the same price the largest come first, so that each
+	// comes after every type that dominates it.
+	sort.SliceStable(usable, func(a, b int) bool {
+		x, y := usable[a], usable[b]
+		if x.Hourly != y.Hourly {
+			return x.Hourly < y.Hourly
+		}
+		if x.VCPU != y.VCPU {
+			return x.VCPU > y.VCPU
+		}
+		return x.Memory > y.Memory
+	})
+	for _, in := range usable {
+		// A type no cheaper than one at least as large is never the better
+		// choice.
+		larger := func(t pricing.InstanceType) bool { return t.VCPU >= in.VCPU && t.Memory >= in.Memory }
+		if !slices.ContainsFunc(p.types, larger) {
+			p.types = append(p.types, in)
+		}
+	}
+
+	for i, g := range w.Pods {
+		if g.Count > 0 {
+			p.groups = append(p.groups, i)
+		}
+		p.totalVCPU += float64(g.Count) * g.VCPU
+		p.totalMem += float64(g.Count) * g.MemoryGiB
+	}
+	sort.SliceStable(p.groups, func(a, b int) bool { return p.size(p.groups[a]) > p.size(p.groups[b]) })
+	return p
+}
+
+// size is the share of the workload's vCPU or memory one pod of group g
+// needs, whichever is larger.
+func (p *planner) size(g int) float64 {
+	pg := p.w.Pods[g]
+	var s float64
+	if p.totalVCPU > 0 {
+		s = max(s, pg.VCPU/p.totalVCPU)
+	}
+	if p.totalMem > 0 {
+		s = max(s, pg.MemoryGiB/p.totalMem)
+	}
+	return s
+}
+
+func (p *planner) capVCPU(in *pricing.InstanceType) float64 {
+	return float64(in.VCPU) - p.w.NodeOverhead.VCPU
+}
+
+func (p *planner) capMem(in *pricing.InstanceType) float64 {
+	return in.Memory - p.w.NodeOverhead.MemoryGiB
+}
+
+// epsilon absorbs rounding in the sums of fractional vCPUs and GiB.
+const epsilon = 1e-9
+
+// room returns how many more pods of group g fit on n: the fewest its free
+// vCPU, its free memory and the group's MaxPerNode allow.
+func (p *planner) room(n *node, g int) int {
+	pg := p.w.Pods[g]
+	k := math.MaxInt
+	if pg.MaxPerNode > 0 {
+		k = pg.MaxPerNode - n.groups[g]
+	}
+	if pg.VCPU > 0 {
+		k = min(k, int(math.Floor((p.capVCPU(n.in)-n.vcpu+epsilon)/pg.VCPU)))
+	}
+	if pg.MemoryGiB > 0 {
+		k = min(k, int(math.Floor((p.capMem(n.in)-n.mem+epsilon)/pg.MemoryGiB)))
+	}
+	return max(k, 0)
+}
+
+// place adds k pods of group g to n.
+func (p *planner) place(n *node, g, k int) {
+	pg := p.w.Pods[g]
+	n.vcpu += float64(k) * pg.VCPU
+	n.mem += float64(k) * pg.MemoryGiB
+	n.groups[g] += k
+}
+
+func (p *planner) newNode(in *pricing.InstanceType) *node {
+	return &node{in: in, groups: make([]int, len(p.w.Pods))}
+}
+
+// plan returns the cheapest fleet it finds for the workload. Bin packing
+// is NP-hard, so this is a heuristic: it packs first fit decreasing onto
+// each single type, and greedily onto whichever type runs the most pods
+// per dollar, then moves every node of each candidate to the cheapest type
+// its pods fit on.
+func (p *planner) plan() (fleet, error) {
+	if len(p.types) == 0 {
+		return nil, fmt.Errorf("no priced instance types larger than the node overhead")
+	}
+	for _, g := range p.groups {
+		ok := false
+		for i := range p.types {
+			if p.room(p.newNode(&p.types[i]), g) > 0 {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			pg := p.w.Pods[g]
+			return nil, fmt.Errorf("%s: a pod with %v vCPU and %v GiB fits on no instance type", pg.Name, pg.VCPU, pg.MemoryGiB)
+		}
+	}
+
+	var best fleet
+	consider := func(f fleet) {
+		if f == nil {
+			return
+		}
+		p.downsize(f)
+		if best == nil || f.hourly() < best.hourly()-epsilon {
+			best = f
+		}
+	}
+	for i := range p.types {
+		consider(p.firstFit(&p.types[i]))
+	}
+	consider(p.greedy())
+	return best, nil
+}
+
+// firstFit packs every pod onto nodes of type in, each group in turn onto
+// the first nodes with room, or returns nil if a pod does not fit on one.
+func (p *planner) firstFit(in *pricing.InstanceType) fleet {
+	var f fleet
+	for _, g := range p.groups {
+		left := p.w.Pods[g].Count
+		for _, n := range f {
+			if left == 0 {
+				break
+			}
+			k := min(left, p.room(n, g))
+			p.place(n, g, k)
+			left -= k
+		}
+		for left > 0 {
+			n := p.newNode(in)
+			k := min(left, p.room(n, g))
+			if k == 0 {
+				return nil
+			}
+			p.place(n, g, k)
+			left -= k
+			f = append(f, n)
+		}
+	}
+	return f
+}
+
+// greedy adds nodes one at a time, each of the type that places the most
+// of the remaining pods, by size, per dollar.
+func (p *planner) greedy() fleet {
+	left := make([]int, len(p.w.Pods))
+	var pods int
+	for _, g := range p.groups {
+		left[g] = p.w.Pods[g].Count
+		pods += left[g]
+	}
+
+	var f fleet
+	for pods > 0 {
+		var (
+			bestNode  *node
+			bestScore float64
+		)
+		for i := range p.types {
+			n := p.newNode(&p.types[i])
+			var size float64
+			for _, g := range p.groups {
+				k := min(left[g], p.room(n, g))
+				p.place(n, g, k)
+				size += float64(k) * p.size(g)
+			}
+			if size == 0 {
+				continue
+			}
+			if score := size / n.in.Hourly; bestNode == nil || score > bestScore {
+				bestNode, bestScore = n, score
+			}
+		}
+		if bestNode == nil {
+			return nil
+		}
+		// The same node is again the best while its pods remain, so
+		// add as many of it as they fill.
+		for {
+			for g, k := range bestNode.groups {
+				left[g] -= k
+				pods -= k
+			}
+			f = append(f, bestNode)
+			if !remain(bestNode, left) {
+				break
+			}
+			bestNode = &node{in: bestNode.in, vcpu: bestNode.vcpu, mem: bestNode.mem, groups: slices.Clone(bestNode.groups)}
+		}
+	}
+	return f
+}
+
+// remain reports whether the pods left fill another node like n.
+func remain(n *node, left []int) bool {
+	for g, k := range n.groups {
+		if k > left[g] {
+			return false
+		}
+	}
+	return true
+}
+
+// downsize moves each node to the cheapest type its pods fit on.
+func (p *planner) downsize(f fleet) {
+	for _, n := range f {
+		for i := range p.types {
+			in := &p.types[i]
+			if in.Hourly >= n.in.Hourly {
+				break
+			}
+			if n.vcpu <= p.capVCPU(in)+epsilon && n.mem <= p.capMem(in)+epsilon {
+				n.in = in
+				break
+			}
+		}
+	}
+}
+
+// planFleet implements the plan command, which sizes the cheapest fleet of
+// instances for a workload of pods.
+func planFleet(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	workloadFile := fs.String("workload", "", "JSON workload description `FILE` (required)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] plan -workload FILE [type pattern...]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *workloadFile == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *priceFile == "" && len(regionList()) != 1 {
+		log.Fatal("plan needs a single -region")
+	}
+	if tableFormats[*outFormat] == nil {
+		log.Fatalf("plan cannot write -format %s", *outFormat)
+	}
+	f, err := os.Open(*workloadFile)
+	checkErr(err, "Open workload")
+	w, err := readWorkload(f)
+	checkErr(err, "Read workload")
+	f.Close()
+	opts := loadOptions()
+	opts.Types = fs.Args()
+	keep := mustParseWhere(*where, &opts)
+	instances := filterWhere(loadInstances(opts), keep)
+
+	fleet, err := newPlanner(w, instances).plan()
+	checkErr(err, "plan")
+	checkErr(printFleet(os.Stdout, w, fleet, *outFormat), "Write "+*outFormat)
+}
+
+// fleetTable returns the node count and cost of each type in a fleet,
+// cheapest first, followed by the fleet's totals. csv gives the costs as
+// plain numbers rather than currency.
+func fleetTable(f fleet, csv bool) *table {
+	counts := make(map[*pricing.InstanceType]int)
+	var types []*pricing.InstanceType
+	for _, n := range f {
+		if counts[n.in] == 0 {
+			types = append(types, n.in)
+		}
+		counts[n.in]++
+	}
+	sort.Slice(types, func(a, b int) bool { return types[a].Hourly < types[b].Hourly })
+
+	t := &table{cols: []column{
+		{name: "type"},
+		{name: "nodes", numeric: true},
+		{name: "hourly", numeric: true},
+		{name: "annual", numeric: true},
+	}}
+	cost := currency
+	if csv {
+		cost = func(v float64) string { return toS(v) }
+	}
+	row := func(name string, nodes int, hourly float64) {
+		t.rows = append(t.rows, []string{name, toS(nodes), cost(hourly), cost(hourly * pricing.HoursPerYear)})
+	}
+	for _, in := range types {
+		row(in.Name, counts[in], float64(counts[in])*in.Hourly)
+	}
+	row("total", len(f), f.hourly())
+	return t
+}
+
+// printFleet writes the fleetTable of f in format and, in the col format,
+// a summary of how much of the fleet the workload uses.
+func printFleet(out io.Writer, w workload, f fleet, format string) error {
+	if err := tableFormats[format](out, fleetTable(f, format == "csv")); err != nil {
+		return err
+	}
+	if format != "col" {
+		return nil
+	}
+
+	var vcpu, mem, capVCPU, capMem float64
+	for _, n := range f {
+		vcpu += n.vcpu
+		mem += n.mem
+		capVCPU += float64(n.in.VCPU)
+		capMem += n.in.Memory
+	}
+	var pods int
+	for _, g := range w.Pods {
+		pods += g.Count
+	}
+	nodes := float64(len(f))
+	_, err := fmt.Fprintf(out, "\n%d pods request %s of %s vCPU and %s of %s GiB; node overhead takes %s vCPU and %s GiB\n",
+		pods, trimFloat(vcpu), trimFloat(capVCPU), trimFloat(mem), trimFloat(capMem),
+		trimFloat(nodes*w.NodeOverhead.VCPU), trimFloat(nodes*w.NodeOverhead.MemoryGiB))
+	return err
+}
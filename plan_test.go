@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+var planTypes = []pricing.InstanceType{
+	{Name: "c.large", VCPU: 2, Memory: 4, Hourly: 0.085},
+	{Name: "m.large", VCPU: 2, Memory: 8, Hourly: 0.096},
+	{Name: "m.xlarge", VCPU: 4, Memory: 16, Hourly: 0.192},
+	{Name: "r.xlarge", VCPU: 4, Memory: 32, Hourly: 0.252},
+	{Name: "unpriced.huge", VCPU: 96, Memory: 768},
+}
+
+func planCounts(t *testing.T, js string) (map[string]int, float64) {
+	t.Helper()
+	w, err := readWorkload(strings.NewReader(js))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := newPlanner(w, planTypes).plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for _, n := range f {
+		counts[n.in.Name]++
+	}
+	return counts, f.hourly()
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		js     string
+		counts map[string]int
+	}{
+		{
+			"one type",
+			`{"pods": [{"count": 4, "vcpu": 1, "memory_gib": 2}]}`,
+			map[string]int{"c.large": 2},
+		},
+		{
+			"overhead",
+			`{"node_overhead": {"vcpu": 0.5, "memory_gib": 1}, "pods": [{"count": 4, "vcpu": 1, "memory_gib": 2}]}`,
+			map[string]int{"c.large": 1, "m.xlarge": 1},
+		},
+		{
+			"anti-affinity",
+			`{"pods": [{"count": 3, "vcpu": 0.25, "memory_gib": 0.5, "max_per_node": 1}]}`,
+			map[string]int{"c.large": 3},
+		},
+		{
+			"mix",
+			`{"pods": [
+				{"name": "db", "count": 1, "vcpu": 2, "memory_gib": 24},
+				{"name": "web", "count": 3, "vcpu": 1, "memory_gib": 2}
+			]}`,
+			map[string]int{"r.xlarge": 1, "c.large": 1},
+		},
+	}
+	for _, tc := range tests {
+		counts, _ := planCounts(t, tc.js)
+		if len(counts) != len(tc.counts) {
+			t.Errorf("%s: got %v, want %v", tc.name, counts, tc.counts)
+			continue
+		}
+		for name, n := range tc.counts {
+			if counts[name] != n {
+				t.Errorf("%s: got %v, want %v", tc.name, counts, tc.counts)
+				break
+			}
+		}
+	}
+
+	w, _ := readWorkload(strings.NewReader(`{"pods": [{"name": "big", "count": 1, "vcpu": 64, "memory_gib": 1}]}`))
+	if _, err := newPlanner(w, planTypes).plan(); err == nil || !strings.Contains(err.Error(), "big") {
+		t.Errorf("oversized pod: got err %v", err)
+	}
+}
+
+func TestPlannerTypes(t *testing.T) {
+	types := append([]pricing.InstanceType{
+		{Name: "c.large.dear", VCPU: 2, Memory: 4, Hourly: 0.09},
+		{Name: "m.large.twin", VCPU: 2, Memory: 8, Hourly: 0.096},
+		{Name: "m.large.small", VCPU: 2, Memory: 6, Hourly: 0.096},
+	}, planTypes...)
+	w, _ := readWorkload(strings.NewReader(`{"pods": [{"count": 1, "vcpu": 1}]}`))
+	var got []string
+	for _, in := range newPlanner(w, types).types {
+		got = append(got, in.Name)
+	}
+	exp := []string{"c.large", "m.large.twin", "m.xlarge", "r.xlarge"}
+	if !slices.Equal(got, exp) {
+		t.Errorf("got %v, want %v", got, exp)
+	}
+
+	// Many pods are placed by the group, not one at a time.
+	counts, _ := planCounts(t, `{"pods": [{"count": 100000, "vcpu": 0.5, "memory_gib": 1}, {"count": 1000, "vcpu": 0.1, "memory_gib": 0.1, "max_per_node": 1}]}`)
+	if counts["c.large"] == 0 {
+		t.Errorf("got %v, want c.large nodes", counts)
+	}
+}
+
+func TestReadWorkloadErrors(t *testing.T) {
+	for _, js := range []string{
+		`{}`,
+		`{"pods": [{"count": 1}]}`,
+		`{"pods": [{"count": -1, "vcpu": 1}]}`,
+		`{"pods": [{"count": 1, "vcpu": 1, "cpu": 2}]}`,
+		`{"node_overhead": {"vcpu": -1}, "pods": [{"count": 1, "vcpu": 1}]}`,
+	} {
+		if _, err := readWorkload(strings.NewReader(js)); err == nil {
+			t.Errorf("readWorkload(%s) = nil error", js)
+		}
+	}
+}
+
+func TestPrintFleet(t *testing.T) {
+	w, err := readWorkload(strings.NewReader(`{"node_overhead": {"vcpu": 0.5}, "pods": [{"count": 3, "vcpu": 1, "memory_gib": 2, "max_per_node": 1}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := newPlanner(w, planTypes).plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printFleet(&buf, w, f, "col"); err != nil {
+		t.Fatal(err)
+	}
+	exp := `   type nodes  hourly    annual
+c.large     3 $0.2550 $2,233.80
+  total     3 $0.2550 $2,233.80
+
+3 pods request 3 of 6 vCPU and 6 of 12 GiB; node overhead takes 1.5 vCPU and 0 GiB
+`
+	if buf.String() != exp {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), exp)
+	}
+
+	buf.Reset()
+	if err := printFleet(&buf, w, f, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	exp = "type,nodes,hourly,annual\nc.large,3,0.255,2233.800\ntotal,3,0.255,2233.800\n"
+	if buf.String() != exp {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), exp)
+	}
+}
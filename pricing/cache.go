@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// CacheSource fetches documents over http and keeps a copy of each under
+// Dir, keyed by offer and region. Cached copies are revalidated with
+// conditional requests. Region price documents are addressed by versioned
+// URLs, so one whose URL is unchanged since it was cached is served without
+// a request at all.
+type CacheSource struct {
+	Dir     string
+	BaseURL string       // defaults to DefaultBaseURL
+	Client  *http.Client // defaults to http.DefaultClient
+}
+
+// cacheMeta is stored next to each cached document.
+type cacheMeta struct {
+	URL             string    `json:"url"`
+	ETag            string    `json:"etag,omitempty"`
+	LastModified    string    `json:"last_modified,omitempty"`
+	PublicationDate string    `json:"publication_date,omitempty"`
+	Fetched         time.Time `json:"fetched"`
+}
+
+// DefaultCacheDir returns the ec2price directory under the user's cache
+// directory, or the empty string if there is none.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ec2price")
+}
+
+func (s CacheSource) path(ref DocRef) string {
+	switch {
+	case ref.Offer == "":
+		return filepath.Join(s.Dir, "index.json")
+	case ref.Region == "":
+		return filepath.Join(s.Dir, ref.Offer, "region-index.json")
+	}
+	return filepath.Join(s.Dir, ref.Offer, ref.Region+".json")
+}
+
+func (s CacheSource) Open(ctx context.Context, ref DocRef) (io.ReadCloser, error) {
+	path := s.path(ref)
+
+	var meta cacheMeta
+	if b, err := os.ReadFile(path + ".meta"); err == nil {
+		if err := json.Unmarshal(b, &meta); err != nil || meta.URL != ref.Path {
+			meta = cacheMeta{}
+		}
+	}
+
+	if meta.URL != "" && ref.Region != "" {
+		if f, err := os.Open(path); err == nil {
+			return f, nil
+		}
+		meta = cacheMeta{}
+	}
+
+	hdr := make(http.Header)
+	if meta.ETag != "" {
+		hdr.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		hdr.Set("If-Modified-Since", meta.LastModified)
+	}
+
+	r, err := get(ctx, s.Client, baseURL(s.BaseURL)+ref.Path, hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.StatusCode == http.StatusNotModified {
+		r.Body.Close()
+		f, err := os.Open(path)
+		if err == nil {
+			return f, nil
+		}
+		// The document went missing from under its metadata; fetch it
+		// again unconditionally.
+		os.Remove(path + ".meta")
+		return s.Open(ctx, ref)
+	}
+
+	if r.StatusCode != 200 {
+		return nil, statusError(r)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		r.Body.Close()
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		r.Body.Close()
+		return nil, err
+	}
+
+	cw := &cacheWriter{
+		body: r.Body,
+		tmp:  tmp,
+		path: path,
+		meta: cacheMeta{
+			URL:          ref.Path,
+			ETag:         r.Header.Get("ETag"),
+			LastModified: r.Header.Get("Last-Modified"),
+			Fetched:      time.Now(),
+		},
+	}
+	cw.r = io.TeeReader(r.Body, io.MultiWriter(tmp, &cw.head))
+	return cw, nil
+}
+
+// cacheWriter copies a response body into the cache as it is read. The copy
+// only replaces the cached document if the body was read to the end.
+type cacheWriter struct {
+	r    io.Reader
+	body io.ReadCloser
+	tmp  *os.File
+	path string
+	meta cacheMeta
+	head headBuffer
+	eof  bool
+	err  error
+}
+
+func (cw *cacheWriter) Read(p []byte) (int, error) {
+	n, err := cw.r.Read(p)
+	if err == io.EOF {
+		cw.eof = true
+	} else if err != nil {
+		cw.err = err
+	}
+	return n, err
+}
+
+func (cw *cacheWriter) Close() error {
+	// JSON decoders stop at the end of the value, which usually leaves a
+	// trailing newline unread.
+	if !cw.eof && cw.err == nil {
+		io.CopyN(io.Discard, cw, 4096)
+	}
+	cw.body.Close()
+
+	tmpName := cw.tmp.Name()
+	if err := cw.tmp.Close(); err != nil || !cw.eof {
+		os.Remove(tmpName)
+		return nil
+	}
+
+	if m := publicationDateRE.FindSubmatch(cw.head.Bytes()); m != nil {
+		cw.meta.PublicationDate = string(m[1])
+	}
+
+	if err := os.Rename(tmpName, cw.path); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	b, err := json.Marshal(cw.meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cw.path+".meta", b, 0644)
+}
+
+// publicationDate appears near the top of every offer document, so it can
+// be picked out of the first few KB without decoding the whole thing.
+var publicationDateRE = regexp.MustCompile(`"publicationDate"\s*:\s*"([^"]+)"`)
+
+// headBuffer keeps the first few KB written to it and discards the rest.
+type headBuffer struct {
+	bytes.Buffer
+}
+
+func (h *headBuffer) Write(p []byte) (int, error) {
+	if room := 4096 - h.Len(); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		h.Buffer.Write(p[:room])
+	}
+	return len(p), nil
+}
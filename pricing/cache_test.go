@@ -0,0 +1,82 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCacheSource(t *testing.T) {
+	var requests, notModified int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, `{"publicationDate":"2026-01-01T00:00:00Z","path":"`+r.URL.Path+`"}`+"\n")
+	}))
+	defer ts.Close()
+
+	src := CacheSource{Dir: t.TempDir(), BaseURL: ts.URL}
+
+	read := func(ref DocRef) string {
+		t.Helper()
+		rc, err := src.Open(context.Background(), ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc struct {
+			Path string `json:"path"`
+		}
+		if err := json.NewDecoder(rc).Decode(&doc); err != nil {
+			t.Fatal(err)
+		}
+		rc.Close()
+		return doc.Path
+	}
+
+	index := DocRef{Path: "/index.json"}
+	prices := DocRef{Offer: EC2Offer, Region: "us-east-1", Path: "/v1/us-east-1.json"}
+
+	for i := 0; i < 2; i++ {
+		if got := read(index); got != index.Path {
+			t.Fatalf("index path got=%q exp=%q", got, index.Path)
+		}
+		if got := read(prices); got != prices.Path {
+			t.Fatalf("prices path got=%q exp=%q", got, prices.Path)
+		}
+	}
+
+	// The index is revalidated, the versioned price document is not.
+	if requests != 3 || notModified != 1 {
+		t.Errorf("requests=%d notModified=%d, exp 3 and 1", requests, notModified)
+	}
+
+	b, err := os.ReadFile(src.path(prices) + ".meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta cacheMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.ETag != `"v1"` || meta.PublicationDate != "2026-01-01T00:00:00Z" {
+		t.Errorf("meta mismatch: %+v", meta)
+	}
+
+	// A new version URL for the region is fetched again.
+	prices.Path = "/v2/us-east-1.json"
+	if got := read(prices); got != prices.Path {
+		t.Fatalf("prices path got=%q exp=%q", got, prices.Path)
+	}
+	if requests != 4 {
+		t.Errorf("requests=%d, exp 4", requests)
+	}
+}
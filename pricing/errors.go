@@ -0,0 +1,74 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrOfferNotFound is returned when the offer index does not list the EC2
+// offer.
+var ErrOfferNotFound = errors.New("offer not found in offer index")
+
+// An UnknownRegionError is returned when a region is not in the EC2 price
+// list.
+type UnknownRegionError struct {
+	Region      string
+	Suggestions []string // close matches, best first
+}
+
+func (e *UnknownRegionError) Error() string {
+	msg := fmt.Sprintf("unknown region %q", e.Region)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean " + strings.Join(e.Suggestions, " or ") + "?"
+	}
+	return msg
+}
+
+// An AmbiguousSKUError is passed to Options.Logf when more than one SKU
+// matches the options for an instance type. The first SKU is used.
+type AmbiguousSKUError struct {
+	InstanceType string
+	Region       string
+	SKUs         []string // sorted
+}
+
+func (e *AmbiguousSKUError) Error() string {
+	return fmt.Sprintf("%s %s: ambiguous SKUs %s, using %s", e.Region, e.InstanceType, strings.Join(e.SKUs, ","), e.SKUs[0])
+}
+
+// A StatusError is returned when the pricing service responds with anything
+// other than 200 OK.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Body       []byte // the start of the response body
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: status %d\n%s", e.URL, e.StatusCode, e.Body)
+}
+
+// A DocError records which document failed to open or decode.
+type DocError struct {
+	Ref DocRef
+	Err error
+}
+
+func (e *DocError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Ref.describe(), e.Err)
+}
+
+func (e *DocError) Unwrap() error {
+	return e.Err
+}
+
+func (ref DocRef) describe() string {
+	switch {
+	case ref.Offer == "":
+		return "offer index"
+	case ref.Region == "":
+		return ref.Offer + " region index"
+	}
+	return ref.Offer + " " + ref.Region + " price list"
+}
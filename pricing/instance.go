@@ -0,0 +1,233 @@
+package pricing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type CPUManufacturer int
+
+const (
+	CPUIntel CPUManufacturer = 1
+	CPUAMD   CPUManufacturer = 2
+	CPUAWS   CPUManufacturer = 3
+)
+
+func (c CPUManufacturer) String() string {
+	switch c {
+	case CPUIntel:
+		return "int"
+	case CPUAMD:
+		return "amd"
+	case CPUAWS:
+		return "arm"
+	}
+
+	return "unk"
+}
+
+func mfgrFromString(s string) CPUManufacturer {
+	if strings.Contains(s, "Intel") {
+		return CPUIntel
+	} else if strings.Contains(s, "AMD") {
+		return CPUAMD
+	} else if strings.Contains(s, "AWS") {
+		return CPUAWS
+	}
+	return 0
+}
+
+// InstanceType is the priced summary of one EC2 instance type.
+type InstanceType struct {
+	Name   string // e.g. "m5.large"
+	Region string // with LoadDoc, the regionCode of the product, if any
+	Family string // e.g. "m5"
+
+	SKU             string   // the product priced
+	OtherSKUs       []string // other products that matched, if ambiguous; see AmbiguousSKUError
+	PublicationDate string   // of the price list, e.g. "2026-01-01T00:00:00Z"
+	Version         string   // of the price list, e.g. "20260101000000"
+
+	OperatingSystem string // e.g. "Linux"
+	PreInstalledSW  string // e.g. "NA" or "SQL Std"
+	Tenancy         string // one of the Tenancy constants
+	CapacityStatus  string // one of the Capacity constants
+
+	VCPU           int
+	Memory         float64 // GiB
+	Disk           Disk
+	Hourly         float64
+	HourlySource   PriceSource // where Hourly was read from
+	OnDemandAnnual float64
+	ReservedAnnual float64 // effective annual cost under DefaultRITerm
+	LinuxHourly    float64 // plain Linux on-demand price, if Options.LinuxPremium
+
+	Reserved     []ReservedPrice    // every reserved offering, in AllRITerms order
+	SavingsPlans []SavingsPlanPrice // every Savings Plans offering, if Options.SavingsPlans, in AllSPTerms order
+	Spot         *SpotPrice         // set by SpotHistory.Apply
+	CPUMfgr      CPUManufacturer
+	CurrentGen   bool
+	NetworkPerf  NetworkPerf
+
+	// Attributes, OnDemandTerms and ReservedTerms are the price list's
+	// product attributes and terms for SKU, as loaded. The terms are keyed
+	// by SKU and offer term code, e.g. "SKU.JRTCKXETXF".
+	Attributes    ProductAttributes
+	OnDemandTerms map[string]Term
+	ReservedTerms map[string]Term
+
+	// HostFits is set for Dedicated Hosts and lists how many instances of
+	// each size in the family fit on one host, largest count first.
+	HostFits []HostFit
+}
+
+// A PriceSource identifies the term and rate in the price list a price was
+// read from.
+type PriceSource struct {
+	OfferTermCode   string // e.g. "JRTCKXETXF"
+	RateCode        string // of the hourly rate, e.g. "SKU.JRTCKXETXF.6YS6EN2CT7"
+	UpfrontRateCode string // of the upfront fee of a reserved term, if any
+	EffectiveDate   string // of the term
+}
+
+// A HostFit is how many instances of Type fit on one Dedicated Host.
+type HostFit struct {
+	Type  string
+	Count int
+}
+
+type NetworkPerf struct {
+	CapGb    float64
+	Bursting bool
+}
+
+func (np NetworkPerf) String() string {
+	var burstIndicator string
+
+	if np.Bursting {
+		burstIndicator = "*"
+	}
+	return fmt.Sprintf("%0.1f%s", np.CapGb, burstIndicator)
+}
+
+var netPerfRE = regexp.MustCompile(`(Up to )?(\d+) (Gigabit|Megabit)`)
+
+func parseNetPerf(n string) (NetworkPerf, error) {
+	var perf NetworkPerf
+
+	m := netPerfRE.FindStringSubmatch(n)
+	if len(m) > 0 {
+
+		nStr := m[2]
+		f, _ := strconv.ParseFloat(nStr, 64)
+		if m[3] == "Megabit" {
+			f = f / 1000
+		}
+
+		perf.CapGb = f
+		if m[1] != "" {
+			perf.Bursting = true
+		}
+
+		return perf, nil
+	}
+
+	words := map[string]NetworkPerf{
+		"Very Low": {
+			CapGb:    0.01,
+			Bursting: true,
+		},
+		"High": {
+			CapGb: 1,
+		},
+		"Low": {
+			CapGb:    0.01,
+			Bursting: true,
+		},
+		"Low to Moderate": {
+			CapGb:    0.01,
+			Bursting: true,
+		},
+		"Moderate": {
+			CapGb:    0.1,
+			Bursting: true,
+		},
+		"NA": {
+			CapGb: 1,
+		},
+	}
+
+	if match, found := words[n]; found {
+		return match, nil
+	}
+
+	return NetworkPerf{}, fmt.Errorf("failed to parse network perf: %q", n)
+}
+
+type Disk struct {
+	Count     int // count 0 means EBSOnly
+	PerDiskGB int
+	SSD       bool
+	NVMe      bool
+}
+
+func (d Disk) String() string {
+	if d.Count == 0 {
+		return "EBS"
+	}
+	suffix := "GB"
+	total := d.Count * d.PerDiskGB
+	if total > 1000*1000 {
+		suffix = "PB"
+		total /= 1000 * 1000
+	} else if total > 1000 {
+		suffix = "TB"
+		total /= 1000
+	}
+
+	typ := "HDD"
+	if d.NVMe {
+		typ = "NVMe"
+	} else if d.SSD {
+		typ = "SSD"
+	}
+
+	return fmt.Sprintf("%d%s-%s", total, suffix, typ)
+}
+
+var diskRE = regexp.MustCompile(`(?i)(?:(\d+) x )?(\d+)(?:GB| GB)?( NVMe)?(?: (SSD|HDD))?`)
+
+func parseStorage(s string) (Disk, error) {
+	var d Disk
+	if s == "EBS only" {
+		return d, nil
+	}
+
+	m := diskRE.FindStringSubmatch(s)
+
+	if len(m) < 1 {
+		return d, fmt.Errorf("parse storage fail for %q", s)
+	}
+
+	d.Count = 1
+
+	if m[1] != "" {
+		d.Count, _ = strconv.Atoi(m[1])
+	}
+
+	d.PerDiskGB, _ = strconv.Atoi(m[2])
+
+	if m[3] != "" {
+		d.NVMe = true
+		d.SSD = true
+	}
+
+	// If type is specified as SSD or if GB is present without type (i8g's do this)
+	if m[4] == "SSD" || (m[4] == "" && strings.Contains(s, "GB")) {
+		d.SSD = true
+	}
+
+	return d, nil
+}
@@ -0,0 +1,167 @@
+package pricing
+
+// The types in this file mirror the documents served by the AWS Price List
+// bulk API.
+
+type PriceIndex struct {
+	Disclaimer      string           `json:"disclaimer"`
+	FormatVersion   string           `json:"formatVersion"`
+	Offers          map[string]Offer `json:"offers"`
+	PublicationDate string           `json:"publicationDate"`
+}
+
+type Offer struct {
+	CurrentRegionIndexURL string `json:"currentRegionIndexUrl"`
+	CurrentVersionURL     string `json:"currentVersionUrl"`
+	OfferCode             string `json:"offerCode"`
+	VersionIndexURL       string `json:"versionIndexUrl"`
+}
+
+type RegionIndex struct {
+	Disclaimer      string `json:"disclaimer"`
+	FormatVersion   string `json:"formatVersion"`
+	PublicationDate string `json:"publicationDate"`
+	Regions         map[string]struct {
+		CurrentVersionURL string `json:"currentVersionUrl"`
+		RegionCode        string `json:"regionCode"`
+	} `json:"regions"`
+}
+
+type PriceDoc struct {
+	Disclaimer      string             `json:"disclaimer"`
+	FormatVersion   string             `json:"formatVersion"`
+	PublicationDate string             `json:"publicationDate"`
+	OfferCode       string             `json:"offerCode"`
+	Products        map[string]Product `json:"products"`
+	Terms           struct {
+		OnDemand map[string]map[string]Term `json:"OnDemand"`
+		Reserved map[string]map[string]Term `json:"Reserved"`
+	} `json:"terms"`
+	Version string `json:"version"`
+}
+
+type Term struct {
+	EffectiveDate   string                    `json:"effectiveDate"`
+	OfferTermCode   string                    `json:"offerTermCode"`
+	PriceDimensions map[string]PriceDimension `json:"priceDimensions"`
+	Sku             string                    `json:"sku"`
+	TermAttributes  struct {
+		LeaseContractLength string `json:"LeaseContractLength"`
+		OfferingClass       string `json:"OfferingClass"`
+		PurchaseOption      string `json:"PurchaseOption"`
+	} `json:"termAttributes"`
+}
+
+type PriceDimension struct {
+	AppliesTo    []interface{}     `json:"appliesTo"`
+	BeginRange   string            `json:"beginRange"`
+	Description  string            `json:"description"`
+	EndRange     string            `json:"endRange"`
+	PricePerUnit map[string]string `json:"pricePerUnit"`
+	RateCode     string            `json:"rateCode"`
+	Unit         string            `json:"unit"`
+}
+
+type Product struct {
+	Attributes    ProductAttributes `json:"attributes"`
+	ProductFamily string            `json:"productFamily"`
+	Sku           string            `json:"sku"`
+}
+
+type ProductAttributes struct {
+	CapacityStatus              string `json:"capacitystatus"`
+	ClockSpeed                  string `json:"clockSpeed"`
+	CurrentGeneration           string `json:"currentGeneration"`
+	DedicatedEBSThroughput      string `json:"dedicatedEbsThroughput"`
+	ECU                         string `json:"ecu"`
+	EnhancedNetworkingSupported string `json:"enhancedNetworkingSupported"`
+	GPU                         string `json:"gpu"`
+	InstanceFamily              string `json:"instanceFamily"`
+	InstanceType                string `json:"instanceType"`
+	IntelAVX2Available          string `json:"intelAvx2Available"`
+	IntelAVXAvailable           string `json:"intelAvxAvailable"`
+	IntelTurboAvailable         string `json:"intelTurboAvailable"`
+	LicenseModel                string `json:"licenseModel"`
+	Location                    string `json:"location"`
+	LocationType                string `json:"locationType"`
+	Memory                      string `json:"memory"`
+	NetworkPerformance          string `json:"networkPerformance"`
+	NormalizationSizeFactor     string `json:"normalizationSizeFactor"`
+	OperatingSystem             string `json:"operatingSystem"`
+	Operation                   string `json:"operation"`
+	PhysicalCores               string `json:"physicalCores"`
+	PhysicalProcessor           string `json:"physicalProcessor"`
+	PreInstalledSW              string `json:"preInstalledSw"`
+	ProcessorArchitecture       string `json:"processorArchitecture"`
+	ProcessorFeatures           string `json:"processorFeatures"`
+	RegionCode                  string `json:"regionCode"`
+	ServiceCode                 string `json:"servicecode"`
+	ServiceName                 string `json:"servicename"`
+	Storage                     string `json:"storage"`
+	Tenancy                     string `json:"tenancy"`
+	UsageType                   string `json:"usagetype"`
+	VCPU                        string `json:"vcpu"`
+}
+
+// SavingsPlanRegionIndex is the region index of a Savings Plans offer. Unlike
+// the EC2 region index its regions are a list.
+type SavingsPlanRegionIndex struct {
+	Disclaimer      string `json:"disclaimer"`
+	PublicationDate string `json:"publicationDate"`
+	Regions         []struct {
+		RegionCode string `json:"regionCode"`
+		VersionURL string `json:"versionUrl"`
+	} `json:"regions"`
+}
+
+type SavingsPlanDoc struct {
+	Disclaimer      string               `json:"disclaimer"`
+	PublicationDate string               `json:"publicationDate"`
+	RegionCode      string               `json:"regionCode"`
+	Version         string               `json:"version"`
+	Products        []SavingsPlanProduct `json:"products"`
+	Terms           struct {
+		SavingsPlan []SavingsPlanTerm `json:"savingsPlan"`
+	} `json:"terms"`
+}
+
+type SavingsPlanProduct struct {
+	Sku           string `json:"sku"`
+	ProductFamily string `json:"productFamily"`
+	ServiceCode   string `json:"serviceCode"`
+	UsageType     string `json:"usageType"`
+	Operation     string `json:"operation"`
+	Attributes    struct {
+		Granularity    string `json:"granularity"`
+		InstanceType   string `json:"instanceType"`
+		Location       string `json:"location"`
+		LocationType   string `json:"locationType"`
+		PurchaseOption string `json:"purchaseOption"`
+		PurchaseTerm   string `json:"purchaseTerm"`
+		RegionCode     string `json:"regionCode"`
+	} `json:"attributes"`
+}
+
+type SavingsPlanTerm struct {
+	Sku                 string `json:"sku"`
+	Description         string `json:"description"`
+	EffectiveDate       string `json:"effectiveDate"`
+	LeaseContractLength struct {
+		Duration int    `json:"duration"`
+		Unit     string `json:"unit"`
+	} `json:"leaseContractLength"`
+	Rates []SavingsPlanRate `json:"rates"`
+}
+
+type SavingsPlanRate struct {
+	DiscountedSku         string `json:"discountedSku"`
+	DiscountedUsageType   string `json:"discountedUsageType"`
+	DiscountedOperation   string `json:"discountedOperation"`
+	DiscountedServiceCode string `json:"discountedServiceCode"`
+	RateCode              string `json:"rateCode"`
+	Unit                  string `json:"unit"`
+	DiscountedRate        struct {
+		Price    string `json:"price"`
+		Currency string `json:"currency"`
+	} `json:"discountedRate"`
+}
@@ -0,0 +1,213 @@
+package pricing
+
+import (
+	"path"
+	"strings"
+)
+
+// Options control which instance types are loaded.
+type Options struct {
+	// Types restricts the load to instance types matching one of these
+	// path.Match patterns, e.g. "m7g.*". All types are loaded if empty.
+	Types []string
+
+	// OperatingSystem is the operatingSystem product attribute to price,
+	// e.g. "Windows", "RHEL", "SUSE" or "Ubuntu Pro". Defaults to "Linux".
+	OperatingSystem string
+
+	// PreInstalledSW is the preInstalledSw product attribute to price, e.g.
+	// "SQL Std", "SQL Ent" or "SQL Web". Defaults to "NA", no pre-installed
+	// software.
+	PreInstalledSW string
+
+	// Tenancy is one of TenancyShared (the default), TenancyDedicated or
+	// TenancyHost. Dedicated Hosts are priced per host rather than per
+	// instance: each InstanceType is a host family, with HostFits listing
+	// how many instances of each size fit on one host. The operating system
+	// and software options do not apply to hosts.
+	Tenancy string
+
+	// CapacityStatus is the capacitystatus product attribute to price: one
+	// of CapacityUsed (the default), CapacityUnusedReservation or
+	// CapacityAllocatedReservation.
+	CapacityStatus string
+
+	// BYOL selects bring-your-own-license pricing instead of license
+	// included.
+	BYOL bool
+
+	// LinuxPremium also loads the price of each type running plain Linux
+	// into InstanceType.LinuxHourly, so the premium of the selected
+	// operating system and software can be shown.
+	LinuxPremium bool
+
+	// SavingsPlans also loads the Savings Plans offer and joins its rates
+	// to each instance type as InstanceType.SavingsPlans. The rate files
+	// are about as large as the EC2 price lists. Regions the offer does not
+	// cover get no rates.
+	SavingsPlans bool
+
+	// Logf, if set, is called with problems that do not stop the load, such
+	// as instance attributes that fail to parse. Calls are not concurrent,
+	// even when LoadRegions loads several regions at once.
+	Logf func(format string, v ...interface{})
+}
+
+// Tenancy values for Options.Tenancy, as they appear in the tenancy product
+// attribute.
+const (
+	TenancyShared    = "Shared"
+	TenancyDedicated = "Dedicated"
+	TenancyHost      = "Host"
+)
+
+// CapacityStatus values for Options.CapacityStatus. Each instance type has
+// an SKU for each: Used is the normal price of running instances, the others
+// price capacity reservations.
+const (
+	CapacityUsed                 = "Used"
+	CapacityUnusedReservation    = "UnusedCapacityReservation"
+	CapacityAllocatedReservation = "AllocatedCapacityReservation"
+)
+
+// usagePrefixes is the usage type prefix of the products of each tenancy
+// and capacity status, e.g. "UnusedBox:m5.large" prices an unused shared
+// capacity reservation.
+var usagePrefixes = map[string]map[string]string{
+	TenancyShared: {
+		CapacityUsed:                 "BoxUsage:",
+		CapacityUnusedReservation:    "UnusedBox:",
+		CapacityAllocatedReservation: "Reservation:",
+	},
+	TenancyDedicated: {
+		CapacityUsed:                 "DedicatedUsage:",
+		CapacityUnusedReservation:    "UnusedDed:",
+		CapacityAllocatedReservation: "DedicatedRes:",
+	},
+	TenancyHost: {
+		CapacityUsed: "HostUsage:",
+	},
+}
+
+func (o *Options) capacityStatus() string {
+	if o.CapacityStatus == "" {
+		return CapacityUsed
+	}
+	return o.CapacityStatus
+}
+
+func (o *Options) tenancy() string {
+	if o.Tenancy == "" {
+		return TenancyShared
+	}
+	return o.Tenancy
+}
+
+// A selection is the product attributes, beyond being an instance type,
+// that pick which SKU prices a type.
+type selection struct {
+	os       string
+	software string
+	byol     bool
+}
+
+func (o *Options) selection() selection {
+	sel := selection{
+		os:       o.OperatingSystem,
+		software: o.PreInstalledSW,
+		byol:     o.BYOL,
+	}
+	if sel.os == "" {
+		sel.os = "Linux"
+	}
+	if sel.software == "" {
+		sel.software = "NA"
+	}
+	return sel
+}
+
+// linuxBaseline is the selection LinuxPremium compares against.
+var linuxBaseline = selection{os: "Linux", software: "NA"}
+
+func (sel selection) matches(attrs *ProductAttributes) bool {
+	return attrs.OperatingSystem == sel.os &&
+		attrs.PreInstalledSW == sel.software &&
+		(attrs.LicenseModel == "Bring your own license") == sel.byol
+}
+
+// selects reports whether a kept product prices the selected instance types,
+// rather than being kept only as the LinuxPremium baseline.
+func (o *Options) selects(attrs *ProductAttributes) bool {
+	return o.selection().matches(attrs)
+}
+
+func (o *Options) keep(p *Product) bool {
+	attrs := &p.Attributes
+
+	if o.tenancy() == TenancyHost {
+		// Shared Linux instances are kept to size the hosts with.
+		if isHost(attrs) {
+			return o.typeMatches(attrs.InstanceType)
+		}
+		return isInstance(attrs, TenancyShared, CapacityUsed) && linuxBaseline.matches(attrs) &&
+			o.typeMatches(attrs.InstanceType)
+	}
+
+	if !isInstance(attrs, o.tenancy(), o.capacityStatus()) {
+		return false
+	}
+	if !o.selects(attrs) && !(o.LinuxPremium && linuxBaseline.matches(attrs)) {
+		return false
+	}
+	return o.typeMatches(attrs.InstanceType)
+}
+
+// isInstance reports whether a product is an instance type running with
+// tenancy and capacity status.
+func isInstance(attrs *ProductAttributes, tenancy, status string) bool {
+	prefix, ok := usagePrefixes[tenancy][status]
+	return ok && strings.Contains(attrs.InstanceType, ".") &&
+		attrs.Tenancy == tenancy && attrs.CapacityStatus == status &&
+		strings.HasPrefix(usageKind(attrs.UsageType), prefix) &&
+		strings.HasPrefix(attrs.Operation, "RunInstances")
+}
+
+// isHost reports whether a product is a Dedicated Host. Their instanceType
+// attribute is the family, e.g. "m5".
+func isHost(attrs *ProductAttributes) bool {
+	return attrs.Tenancy == TenancyHost &&
+		strings.HasPrefix(usageKind(attrs.UsageType), usagePrefixes[TenancyHost][CapacityUsed])
+}
+
+// typeMatches reports whether name matches Options.Types. A host family
+// matches if any of its instance types would.
+func (o *Options) typeMatches(name string) bool {
+	if len(o.Types) == 0 {
+		return true
+	}
+	for _, pattern := range o.Types {
+		if !strings.Contains(name, ".") {
+			pattern, _, _ = strings.Cut(pattern, ".")
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// usageKind strips the region prefix from a usage type, so that
+// "EUW1-BoxUsage:m5.large" in eu-west-1 matches "BoxUsage:m5.large" in
+// us-east-1, which has no prefix.
+func usageKind(usageType string) string {
+	if i := strings.IndexByte(usageType, '-'); i >= 0 && i < strings.IndexByte(usageType, ':') {
+		return usageType[i+1:]
+	}
+	return usageType
+}
+
+func (o *Options) logf(format string, v ...interface{}) {
+	if o.Logf != nil {
+		o.Logf(format, v...)
+	}
+}
@@ -0,0 +1,376 @@
+// Package pricing loads EC2 instance type prices from the AWS Price List
+// bulk API.
+//
+//	instances, err := pricing.Load(ctx, pricing.HTTPSource{}, "us-east-1", pricing.Options{})
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Load fetches the EC2 price list for region from src and returns the
+// instance types in it, sorted by name.
+func Load(ctx context.Context, src Source, region string, opts Options) ([]InstanceType, error) {
+	return LoadRegions(ctx, src, []string{region}, opts)
+}
+
+// LoadRegions fetches the EC2 price lists for regions from src concurrently
+// and returns the instance types in them, sorted by name and then in the
+// order of regions. If regions is empty every region in the price list is
+// loaded.
+func LoadRegions(ctx context.Context, src Source, regions []string, opts Options) ([]InstanceType, error) {
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	regionIdx, err := loadRegionIndex(ctx, src, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	var spRefs map[string]DocRef
+	if opts.SavingsPlans {
+		spRefs, err = loadSavingsPlanIndex(ctx, src, idx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(regions) == 0 {
+		regions = regionIdx.codes()
+	} else if err := regionIdx.validate(regions); err != nil {
+		return nil, err
+	}
+
+	// Regions load concurrently, but Logf is called one at a time.
+	if logf := opts.Logf; logf != nil {
+		var mu sync.Mutex
+		opts.Logf = func(format string, v ...interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			logf(format, v...)
+		}
+	}
+
+	results := make([][]InstanceType, len(regions))
+	err = forEachRegion(ctx, regions, func(ctx context.Context, i int, region string) error {
+		var err error
+		results[i], err = loadRegion(ctx, src, regionIdx.ref(region), spRefs[region], &opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []InstanceType
+	for _, r := range results {
+		instances = append(instances, r...)
+	}
+
+	order := make(map[string]int)
+	for i, region := range regions {
+		order[region] = i
+	}
+	sort.SliceStable(instances, func(a, b int) bool {
+		if instances[a].Name != instances[b].Name {
+			return instances[a].Name < instances[b].Name
+		}
+		return order[instances[a].Region] < order[instances[b].Region]
+	})
+
+	return instances, nil
+}
+
+func loadIndex(ctx context.Context, src Source) (*PriceIndex, error) {
+	var idx PriceIndex
+	if err := decodeDoc(ctx, src, DocRef{Path: IndexPath}, &idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}
+
+// regionIndexRef returns the region index of offer.
+func (idx *PriceIndex) regionIndexRef(offer string) (DocRef, error) {
+	o, ok := idx.Offers[offer]
+	if !ok {
+		return DocRef{}, &DocError{Ref: DocRef{Path: IndexPath}, Err: fmt.Errorf("%s: %w", offer, ErrOfferNotFound)}
+	}
+	return DocRef{Offer: offer, Path: o.CurrentRegionIndexURL}, nil
+}
+
+func loadRegionIndex(ctx context.Context, src Source, idx *PriceIndex) (*RegionIndex, error) {
+	ref, err := idx.regionIndexRef(EC2Offer)
+	if err != nil {
+		return nil, err
+	}
+
+	var regionIdx RegionIndex
+	if err := decodeDoc(ctx, src, ref, &regionIdx); err != nil {
+		return nil, err
+	}
+	return &regionIdx, nil
+}
+
+// loadRegion loads the instance types in the price document ref, joining the
+// Savings Plans rates in spRef if it is set.
+func loadRegion(ctx context.Context, src Source, ref, spRef DocRef, opts *Options) ([]InstanceType, error) {
+	rc, err := src.Open(ctx, ref)
+	if err != nil {
+		return nil, &DocError{Ref: ref, Err: err}
+	}
+	defer rc.Close()
+
+	doc, err := decodePriceDoc(rc, opts.keep)
+	if err != nil {
+		return nil, &DocError{Ref: ref, Err: err}
+	}
+
+	var sp map[string][]SavingsPlanPrice
+	if spRef.Path != "" {
+		sp, err = loadSavingsPlans(ctx, src, spRef, doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buildInstances(doc, ref.Region, sp, opts), nil
+}
+
+// LoadDoc returns the instance types in the region price document read from
+// r, sorted by name. Their Region is the regionCode of their products, if
+// the document has one.
+func LoadDoc(r io.Reader, opts Options) ([]InstanceType, error) {
+	doc, err := decodePriceDoc(r, opts.keep)
+	if err != nil {
+		return nil, err
+	}
+	return buildInstances(doc, "", nil, &opts), nil
+}
+
+func decodeDoc(ctx context.Context, src Source, ref DocRef, v interface{}) error {
+	rc, err := src.Open(ctx, ref)
+	if err != nil {
+		return &DocError{Ref: ref, Err: err}
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return &DocError{Ref: ref, Err: err}
+	}
+	return nil
+}
+
+// buildInstances returns the instance types in doc selected by opts, with the
+// Savings Plans prices in sp of their SKUs.
+func buildInstances(doc *PriceDoc, region string, sp map[string][]SavingsPlanPrice, opts *Options) []InstanceType {
+	var instances []InstanceType
+
+	hosts := opts.tenancy() == TenancyHost
+
+	// On-demand prices of the same types running plain Linux, for
+	// Options.LinuxPremium.
+	linuxHourly := make(map[string]float64)
+
+	// The vCPUs of each size in a family, for sizing Dedicated Hosts.
+	sizes := make(map[string][]HostFit)
+
+	// Products are visited in SKU order so that if several match the
+	// options for a type, which one is used does not change between runs.
+	skus := sortedKeys(doc.Products)
+
+	matched := make(map[string][]string)
+	sized := make(map[string]bool)
+
+	for _, sku := range skus {
+		attrs := doc.Products[sku].Attributes
+
+		if hosts && !isHost(&attrs) {
+			if sized[attrs.InstanceType] {
+				continue
+			}
+			sized[attrs.InstanceType] = true
+			vcpu, _ := strconv.Atoi(attrs.VCPU)
+			family := familyOf(attrs.InstanceType)
+			sizes[family] = append(sizes[family], HostFit{Type: attrs.InstanceType, Count: vcpu})
+			continue
+		} else if !hosts && !opts.selects(&attrs) {
+			if _, ok := linuxHourly[attrs.InstanceType]; !ok {
+				linuxHourly[attrs.InstanceType], _ = onDemandHourly(doc.Terms.OnDemand[sku])
+			}
+			continue
+		}
+
+		matched[attrs.InstanceType] = append(matched[attrs.InstanceType], sku)
+		if len(matched[attrs.InstanceType]) > 1 {
+			continue
+		}
+		// LoadDoc has no region but the products' own.
+		region := region
+		if region == "" {
+			region = attrs.RegionCode
+		}
+
+		reserved := reservedPrices(doc.Terms.Reserved[sku])
+		var reservedAnnual float64
+		for _, rp := range reserved {
+			if rp.Term == DefaultRITerm {
+				reservedAnnual = rp.EffectiveAnnual
+			}
+		}
+
+		hourly, hourlySource := onDemandHourly(doc.Terms.OnDemand[sku])
+		onDemandCost := hourly * HoursPerYear
+
+		memS := strings.TrimSuffix(attrs.Memory, " GiB")
+		memS = strings.ReplaceAll(memS, ",", "")
+		mem, _ := strconv.ParseFloat(memS, 64)
+
+		vcpu, _ := strconv.Atoi(attrs.VCPU)
+
+		if hosts {
+			instances = append(instances, InstanceType{
+				Name:            attrs.InstanceType,
+				Region:          region,
+				Family:          attrs.InstanceType,
+				SKU:             sku,
+				PublicationDate: doc.PublicationDate,
+				Version:         doc.Version,
+				Tenancy:         attrs.Tenancy,
+				VCPU:            vcpu,
+				Memory:          mem,
+				Hourly:          hourly,
+				HourlySource:    hourlySource,
+				OnDemandAnnual:  onDemandCost,
+				ReservedAnnual:  reservedAnnual,
+				Reserved:        reserved,
+				SavingsPlans:    sp[sku],
+				CPUMfgr:         mfgrFromString(attrs.PhysicalProcessor),
+				CurrentGen:      attrs.CurrentGeneration == "Yes",
+				Attributes:      attrs,
+				OnDemandTerms:   doc.Terms.OnDemand[sku],
+				ReservedTerms:   doc.Terms.Reserved[sku],
+			})
+			continue
+		}
+
+		disk, err := parseStorage(attrs.Storage)
+		if err != nil {
+			opts.logf("parse storage for %s err: %s", attrs.InstanceType, err)
+		}
+
+		np, err := parseNetPerf(attrs.NetworkPerformance)
+		if err != nil {
+			opts.logf("parse network for %s err: %s", attrs.InstanceType, err)
+		}
+
+		instances = append(instances, InstanceType{
+			Name:            attrs.InstanceType,
+			Region:          region,
+			Family:          familyOf(attrs.InstanceType),
+			SKU:             sku,
+			PublicationDate: doc.PublicationDate,
+			Version:         doc.Version,
+			OperatingSystem: attrs.OperatingSystem,
+			PreInstalledSW:  attrs.PreInstalledSW,
+			Tenancy:         attrs.Tenancy,
+			CapacityStatus:  attrs.CapacityStatus,
+			VCPU:            vcpu,
+			Memory:          mem,
+			Disk:            disk,
+			Hourly:          hourly,
+			HourlySource:    hourlySource,
+			OnDemandAnnual:  onDemandCost,
+			ReservedAnnual:  reservedAnnual,
+			Reserved:        reserved,
+			SavingsPlans:    sp[sku],
+			CPUMfgr:         mfgrFromString(attrs.PhysicalProcessor),
+			CurrentGen:      attrs.CurrentGeneration == "Yes",
+			NetworkPerf:     np,
+			Attributes:      attrs,
+			OnDemandTerms:   doc.Terms.OnDemand[sku],
+			ReservedTerms:   doc.Terms.Reserved[sku],
+		})
+	}
+
+	if opts.LinuxPremium {
+		for i, in := range instances {
+			if opts.selection() == linuxBaseline {
+				instances[i].LinuxHourly = in.Hourly
+			} else {
+				instances[i].LinuxHourly = linuxHourly[in.Name]
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(matched) {
+		if skus := matched[name]; len(skus) > 1 {
+			opts.logf("%s", &AmbiguousSKUError{InstanceType: name, Region: region, SKUs: skus})
+		}
+	}
+	for i, in := range instances {
+		if skus := matched[in.Name]; len(skus) > 1 {
+			instances[i].OtherSKUs = skus[1:]
+		}
+	}
+
+	if hosts {
+		for i, in := range instances {
+			instances[i].HostFits = hostFits(in.VCPU, sizes[in.Family])
+		}
+	}
+
+	sort.Slice(instances, func(a, b int) bool { return instances[a].Name < instances[b].Name })
+
+	return instances
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func familyOf(instanceType string) string {
+	return strings.SplitN(instanceType, ".", 2)[0]
+}
+
+// hostFits returns how many of each size fit in a host's vCPUs, given the
+// vCPUs of each size as the Count of sizes.
+func hostFits(hostVCPU int, sizes []HostFit) []HostFit {
+	var fits []HostFit
+	for _, size := range sizes {
+		if size.Count == 0 || size.Count > hostVCPU {
+			continue
+		}
+		fits = append(fits, HostFit{Type: size.Type, Count: hostVCPU / size.Count})
+	}
+	sort.Slice(fits, func(a, b int) bool {
+		if fits[a].Count != fits[b].Count {
+			return fits[a].Count > fits[b].Count
+		}
+		return fits[a].Type < fits[b].Type
+	})
+	return fits
+}
+
+// onDemandHourly returns the hourly price of an SKU's on-demand term.
+func onDemandHourly(terms map[string]Term) (float64, PriceSource) {
+	for _, key := range sortedKeys(terms) {
+		od := terms[key]
+		for _, rc := range sortedKeys(od.PriceDimensions) {
+			pd := od.PriceDimensions[rc]
+			f, _ := strconv.ParseFloat(pd.PricePerUnit["USD"], 64)
+			return f, PriceSource{OfferTermCode: od.OfferTermCode, RateCode: pd.RateCode, EffectiveDate: od.EffectiveDate}
+		}
+	}
+	return 0, PriceSource{}
+}
@@ -0,0 +1,393 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	instances, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := instances[0].Attributes.PhysicalProcessor; got != "Intel Xeon Platinum 8175" {
+		t.Errorf("m5.large physicalProcessor = %q", got)
+	}
+	if od := instances[0].OnDemandTerms["SKU1.JRTCKXETXF"]; od.OfferTermCode != "JRTCKXETXF" || len(instances[0].ReservedTerms) != 2 {
+		t.Errorf("m5.large terms: on demand %+v, %d reserved", od, len(instances[0].ReservedTerms))
+	}
+	// The raw attributes and terms are checked above; compare the rest.
+	for i := range instances {
+		instances[i].Attributes = ProductAttributes{}
+		instances[i].OnDemandTerms = nil
+		instances[i].ReservedTerms = nil
+	}
+
+	// Computed at run time to match the loader's float rounding.
+	annual := func(hourly float64) float64 { return hourly * HoursPerYear }
+
+	exp := []InstanceType{
+		{
+			Name:            "m5.large",
+			Region:          "us-east-1",
+			Family:          "m5",
+			SKU:             "SKU1",
+			PublicationDate: "2026-01-01T00:00:00Z",
+			Version:         "20260101000000",
+			OperatingSystem: "Linux",
+			PreInstalledSW:  "NA",
+			Tenancy:         "Shared",
+			CapacityStatus:  "Used",
+			VCPU:            2,
+			Memory:          8,
+			Hourly:          0.096,
+			HourlySource:    PriceSource{"JRTCKXETXF", "SKU1.JRTCKXETXF.6YS6EN2CT7", "", "2026-01-01T00:00:00Z"},
+			OnDemandAnnual:  annual(0.096),
+			ReservedAnnual:  annual(0.07),
+			Reserved: []ReservedPrice{
+				{
+					Term:            RITerm{"1yr", "convertible", "No Upfront"},
+					Hourly:          0.07,
+					EffectiveAnnual: annual(0.07),
+					Source:          PriceSource{"7NE97W5U4E", "SKU1.7NE97W5U4E.6YS6EN2CT7", "", "2026-01-01T00:00:00Z"},
+				},
+				{
+					Term:            RITerm{"3yr", "standard", "Partial Upfront"},
+					Upfront:         300,
+					Hourly:          0.03,
+					EffectiveAnnual: annual(0.03) + 100,
+					Source:          PriceSource{"HU7G6KETJZ", "SKU1.HU7G6KETJZ.6YS6EN2CT7", "SKU1.HU7G6KETJZ.2TG2D8R56U", "2026-01-01T00:00:00Z"},
+				},
+			},
+			CPUMfgr:     CPUIntel,
+			CurrentGen:  true,
+			NetworkPerf: NetworkPerf{CapGb: 10, Bursting: true},
+		},
+		{
+			Name:            "m7g.large",
+			Region:          "us-east-1",
+			Family:          "m7g",
+			SKU:             "SKU2",
+			OtherSKUs:       []string{"SKU7"},
+			PublicationDate: "2026-01-01T00:00:00Z",
+			Version:         "20260101000000",
+			OperatingSystem: "Linux",
+			PreInstalledSW:  "NA",
+			Tenancy:         "Shared",
+			CapacityStatus:  "Used",
+			VCPU:            2,
+			Memory:          8,
+			Hourly:          0.0816,
+			HourlySource:    PriceSource{"JRTCKXETXF", "SKU2.JRTCKXETXF.6YS6EN2CT7", "", "2026-01-01T00:00:00Z"},
+			OnDemandAnnual:  annual(0.0816),
+			CPUMfgr:         CPUAWS,
+			CurrentGen:      true,
+			NetworkPerf:     NetworkPerf{CapGb: 12.5, Bursting: true},
+		},
+	}
+	if !reflect.DeepEqual(instances, exp) {
+		t.Errorf("mismatch:\ngot=%+v\nexp=%+v", instances, exp)
+	}
+
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", Options{Types: []string{"m7g.*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].Name != "m7g.large" {
+		t.Errorf("Types filter got=%+v", instances)
+	}
+}
+
+func TestLoadOperatingSystem(t *testing.T) {
+	opts := Options{
+		OperatingSystem: "Windows",
+		LinuxPremium:    true,
+	}
+	instances, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instances) != 1 {
+		t.Fatalf("got %d instances, exp 1: %+v", len(instances), instances)
+	}
+	in := instances[0]
+	if in.Name != "m5.large" || in.OperatingSystem != "Windows" || in.Hourly != 0.188 || in.LinuxHourly != 0.096 {
+		t.Errorf("mismatch: %+v", in)
+	}
+
+	opts.BYOL = true
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 0 {
+		t.Errorf("BYOL got %d instances, exp 0", len(instances))
+	}
+}
+
+func TestLoadTenancy(t *testing.T) {
+	instances, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", Options{Tenancy: TenancyDedicated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].Name != "m5.large" || instances[0].Hourly != 0.106 {
+		t.Errorf("dedicated mismatch: %+v", instances)
+	}
+
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", Options{Tenancy: TenancyHost})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 {
+		t.Fatalf("got %d hosts, exp 1: %+v", len(instances), instances)
+	}
+	host := instances[0]
+	if host.Name != "m5" || host.VCPU != 96 || host.Hourly != 5.069 {
+		t.Errorf("host mismatch: %+v", host)
+	}
+	if exp := []HostFit{{Type: "m5.large", Count: 48}}; !reflect.DeepEqual(host.HostFits, exp) {
+		t.Errorf("host fits got=%+v exp=%+v", host.HostFits, exp)
+	}
+}
+
+func TestLoadCapacityStatus(t *testing.T) {
+	var logs []string
+	opts := Options{
+		Logf: func(format string, v ...interface{}) { logs = append(logs, fmt.Sprintf(format, v...)) },
+	}
+	_, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"us-east-1 m7g.large: ambiguous SKUs SKU2,SKU7, using SKU2"}
+	if !reflect.DeepEqual(logs, exp) {
+		t.Errorf("logs got=%q exp=%q", logs, exp)
+	}
+
+	opts.CapacityStatus = CapacityUnusedReservation
+	instances, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].Name != "m5.large" || instances[0].CapacityStatus != CapacityUnusedReservation {
+		t.Errorf("unused reservation mismatch: %+v", instances)
+	}
+
+	opts.CapacityStatus = CapacityAllocatedReservation
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].SKU != "SKU8" || instances[0].CapacityStatus != CapacityAllocatedReservation {
+		t.Errorf("allocated reservation mismatch: %+v", instances)
+	}
+
+	opts.CapacityStatus = CapacityUnusedReservation
+	opts.Tenancy = TenancyDedicated
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].SKU != "SKU9" || instances[0].Hourly != 0.106 {
+		t.Errorf("unused dedicated reservation mismatch: %+v", instances)
+	}
+}
+
+func TestLoadSavingsPlans(t *testing.T) {
+	instances, err := LoadRegions(context.Background(), DirSource{Dir: "testdata"}, nil, Options{SavingsPlans: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, in := range instances {
+		for _, sp := range in.SavingsPlans {
+			got = append(got, fmt.Sprintf("%s %s %s %.4f", in.Name, in.Region, sp.Term, sp.Hourly))
+		}
+	}
+	exp := []string{
+		"m5.large us-east-1 compute-1yr-none 0.0690",
+		"m5.large us-east-1 ec2-3yr-all 0.0380",
+		"m7g.large us-east-1 compute-1yr-none 0.0590",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("mismatch:\ngot=%q\nexp=%q", got, exp)
+	}
+
+	term, err := ParseSPTerm("ec2-3yr-all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp, ok := instances[1].SavingsPlanPrice(term); !ok || sp.EffectiveAnnual != 0.038*HoursPerYear {
+		t.Errorf("SavingsPlanPrice(%s) got=%+v,%t", term, sp, ok)
+	}
+}
+
+func TestLoadRegions(t *testing.T) {
+	instances, err := LoadRegions(context.Background(), DirSource{Dir: "testdata"}, nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, in := range instances {
+		got = append(got, fmt.Sprintf("%s %s %.4f", in.Name, in.Region, in.Hourly))
+	}
+	exp := []string{
+		"m5.large eu-west-1 0.1070",
+		"m5.large us-east-1 0.0960",
+		"m7g.large us-east-1 0.0816",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("mismatch:\ngot=%q\nexp=%q", got, exp)
+	}
+}
+
+func TestLoadUnknownRegion(t *testing.T) {
+	_, err := Load(context.Background(), DirSource{Dir: "testdata"}, "us-est-1", Options{})
+
+	var regionErr *UnknownRegionError
+	if !errors.As(err, &regionErr) {
+		t.Fatalf("got err=%v, exp UnknownRegionError", err)
+	}
+	if !reflect.DeepEqual(regionErr.Suggestions, []string{"us-east-1"}) {
+		t.Errorf("suggestions got=%q exp=[us-east-1]", regionErr.Suggestions)
+	}
+}
+
+func TestRegions(t *testing.T) {
+	regions, err := Regions(context.Background(), DirSource{Dir: "testdata"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []RegionInfo{
+		{Code: "eu-west-1", Location: "EU (Ireland)", LocationType: "AWS Region"},
+		{Code: "us-east-1", Location: "US East (N. Virginia)", LocationType: "AWS Region"},
+	}
+	if !reflect.DeepEqual(regions, exp) {
+		t.Errorf("mismatch:\ngot=%+v\nexp=%+v", regions, exp)
+	}
+}
+
+// openCounter counts the documents opened from a Source.
+type openCounter struct {
+	Source
+	mu     sync.Mutex
+	opened []string
+}
+
+func (c *openCounter) Open(ctx context.Context, ref DocRef) (io.ReadCloser, error) {
+	c.mu.Lock()
+	c.opened = append(c.opened, ref.LocalName())
+	c.mu.Unlock()
+	return c.Source.Open(ctx, ref)
+}
+
+func TestRegionsUnknown(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"ec2-price-index.json", "ec2-price-eu-west-1.json"} {
+		b, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "ec2-price-eu-west-1.json" {
+			// A region newer than regionLocations.
+			name = "ec2-price-xx-new-1.json"
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	idx := `{"regions": {
+		"us-east-1": {"regionCode": "us-east-1", "currentVersionUrl": "/us-east-1/index.json"},
+		"xx-new-1": {"regionCode": "xx-new-1", "currentVersionUrl": "/xx-new-1/index.json"},
+		"xx-gone-1": {"regionCode": "xx-gone-1", "currentVersionUrl": "/xx-gone-1/index.json"}
+	}}`
+	if err := os.WriteFile(filepath.Join(dir, "ec2-price-region-index.json"), []byte(idx), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src := &openCounter{Source: DirSource{Dir: dir}}
+	regions, err := Regions(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []RegionInfo{
+		{Code: "us-east-1", Location: "US East (N. Virginia)", LocationType: "AWS Region"},
+		{Code: "xx-new-1", Location: "EU (Ireland)", LocationType: "AWS Region"},
+	}
+	if !reflect.DeepEqual(regions, exp) {
+		t.Errorf("mismatch:\ngot=%+v\nexp=%+v", regions, exp)
+	}
+	sort.Strings(src.opened)
+	if exp := []string{"ec2-price-index.json", "ec2-price-region-index.json", "ec2-price-xx-gone-1.json", "ec2-price-xx-new-1.json"}; !reflect.DeepEqual(src.opened, exp) {
+		t.Errorf("opened %q, exp %q", src.opened, exp)
+	}
+}
+
+func TestParseRITerm(t *testing.T) {
+	for _, term := range AllRITerms {
+		got, err := ParseRITerm(term.String())
+		if err != nil {
+			t.Errorf("parse %q err: %s", term, err)
+		}
+		if got != term {
+			t.Errorf("%q parse mismatch: got=%+v exp=%+v", term, got, term)
+		}
+	}
+
+	for _, in := range []string{"", "1yr-conv", "2yr-conv-none", "1yr-convertible-none", "1yr-std-some"} {
+		if _, err := ParseRITerm(in); err == nil {
+			t.Errorf("parse %q: expected error", in)
+		}
+	}
+}
+
+func TestParseSPTerm(t *testing.T) {
+	for _, term := range AllSPTerms {
+		got, err := ParseSPTerm(term.String())
+		if err != nil {
+			t.Errorf("parse %q err: %s", term, err)
+		}
+		if got != term {
+			t.Errorf("%q parse mismatch: got=%+v exp=%+v", term, got, term)
+		}
+	}
+
+	for _, in := range []string{"", "compute-1yr", "sagemaker-1yr-none", "ec2-2yr-all"} {
+		if _, err := ParseSPTerm(in); err == nil {
+			t.Errorf("parse %q: expected error", in)
+		}
+	}
+}
+
+func TestLoadDocError(t *testing.T) {
+	_, err := Load(context.Background(), DirSource{Dir: "testdata/missing"}, "us-east-1", Options{})
+
+	var docErr *DocError
+	if !errors.As(err, &docErr) || docErr.Ref.Path != IndexPath {
+		t.Errorf("got err=%v, exp DocError for the offer index", err)
+	}
+}
+
+func TestBreakEven(t *testing.T) {
+	rp := ReservedPrice{EffectiveAnnual: 0.05 * HoursPerYear}
+	if got := rp.BreakEven(0.1); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("break-even got=%v exp=0.5", got)
+	}
+	if got := rp.BreakEven(0); !math.IsInf(got, 1) {
+		t.Errorf("break-even with no on-demand price got=%v exp=+Inf", got)
+	}
+}
@@ -0,0 +1,194 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RegionInfo describes a region in the EC2 price list.
+type RegionInfo struct {
+	Code         string // e.g. "eu-west-1"
+	Location     string // e.g. "EU (Ireland)"
+	LocationType string // e.g. "AWS Region"
+}
+
+// Regions returns every region in the EC2 price list, sorted by code. The
+// location of a region is looked up in a table of known regions; only for
+// a region newer than the table is the start of its price list read, and a
+// region whose price list src does not have, as when offline, is left out.
+func Regions(ctx context.Context, src Source) ([]RegionInfo, error) {
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	regionIdx, err := loadRegionIndex(ctx, src, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := regionIdx.codes()
+	regions := make([]RegionInfo, len(codes))
+	err = forEachRegion(ctx, codes, func(ctx context.Context, i int, code string) error {
+		if loc, ok := regionLocations[code]; ok {
+			regions[i] = RegionInfo{Code: code, Location: loc, LocationType: "AWS Region"}
+			return nil
+		}
+
+		ref := regionIdx.ref(code)
+		rc, err := src.Open(ctx, ref)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return &DocError{Ref: ref, Err: err}
+		}
+		defer rc.Close()
+
+		attrs, err := regionAttributes(rc, code)
+		if err != nil {
+			return &DocError{Ref: ref, Err: err}
+		}
+		regions[i] = RegionInfo{
+			Code:         code,
+			Location:     attrs.Location,
+			LocationType: attrs.LocationType,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := regions[:0]
+	for _, r := range regions {
+		if r.Code != "" {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+func (idx *RegionIndex) codes() []string {
+	codes := make([]string, 0, len(idx.Regions))
+	for code := range idx.Regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (idx *RegionIndex) ref(region string) DocRef {
+	return DocRef{
+		Offer:  EC2Offer,
+		Region: region,
+		Path:   idx.Regions[region].CurrentVersionURL,
+	}
+}
+
+// validate returns an UnknownRegionError for the first of regions that is
+// not in idx.
+func (idx *RegionIndex) validate(regions []string) error {
+	for _, region := range regions {
+		if _, ok := idx.Regions[region]; !ok {
+			return &UnknownRegionError{
+				Region:      region,
+				Suggestions: closest(region, idx.codes()),
+			}
+		}
+	}
+	return nil
+}
+
+// closest returns the candidates within a small edit distance of s, closest
+// first.
+func closest(s string, candidates []string) []string {
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(s), c)
+		if d <= 2 {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].dist < matches[b].dist })
+
+	var names []string
+	for i, m := range matches {
+		if i == 3 {
+			break
+		}
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// maxConcurrentFetches bounds how many region price lists are downloaded
+// and decoded at once.
+const maxConcurrentFetches = 4
+
+// forEachRegion calls fn for each of regions concurrently. The context
+// passed to fn is canceled as soon as any call fails, and the first error is
+// returned.
+func forEachRegion(ctx context.Context, regions []string, fn func(ctx context.Context, i int, region string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, maxConcurrentFetches)
+		errOnce sync.Once
+		err     error
+	)
+	for i, region := range regions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			if e := fn(ctx, i, region); e != nil {
+				errOnce.Do(func() {
+					err = e
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
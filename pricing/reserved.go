@@ -0,0 +1,185 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An RITerm identifies a reserved instance offering.
+type RITerm struct {
+	Length         string // "1yr" or "3yr"
+	Class          string // "standard" or "convertible"
+	PurchaseOption string // "No Upfront", "Partial Upfront" or "All Upfront"
+}
+
+// DefaultRITerm is the offering InstanceType.ReservedAnnual is priced with.
+var DefaultRITerm = RITerm{Length: "1yr", Class: "convertible", PurchaseOption: "No Upfront"}
+
+// AllRITerms lists every reserved instance offering, in the order
+// InstanceType.Reserved is sorted in.
+var AllRITerms []RITerm
+
+func init() {
+	for _, length := range riLengths {
+		for _, class := range riClasses {
+			for _, option := range riOptions {
+				AllRITerms = append(AllRITerms, RITerm{length.attr, class.attr, option.attr})
+			}
+		}
+	}
+}
+
+type riName struct {
+	short string
+	attr  string
+}
+
+var (
+	riLengths = []riName{{"1yr", "1yr"}, {"3yr", "3yr"}}
+	riClasses = []riName{{"std", "standard"}, {"conv", "convertible"}}
+	riOptions = []riName{{"none", "No Upfront"}, {"partial", "Partial Upfront"}, {"all", "All Upfront"}}
+)
+
+// String returns the short form of t parsed by ParseRITerm, e.g.
+// "1yr-conv-none".
+func (t RITerm) String() string {
+	return shortName(riLengths, t.Length) + "-" + shortName(riClasses, t.Class) + "-" + shortName(riOptions, t.PurchaseOption)
+}
+
+// Years returns the length of the term in years.
+func (t RITerm) Years() int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(t.Length, "yr"))
+	return n
+}
+
+// ParseRITerm parses the short form of a term, LENGTH-CLASS-OPTION, where
+// LENGTH is 1yr or 3yr, CLASS std or conv and OPTION none, partial or all.
+func ParseRITerm(s string) (RITerm, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return RITerm{}, fmt.Errorf("reserved term %q: want LENGTH-CLASS-OPTION, e.g. 1yr-conv-none", s)
+	}
+
+	var (
+		t   RITerm
+		err error
+	)
+	if t.Length, err = longName(riLengths, parts[0], "reserved term", s, "length"); err != nil {
+		return RITerm{}, err
+	}
+	if t.Class, err = longName(riClasses, parts[1], "reserved term", s, "class"); err != nil {
+		return RITerm{}, err
+	}
+	if t.PurchaseOption, err = longName(riOptions, parts[2], "reserved term", s, "purchase option"); err != nil {
+		return RITerm{}, err
+	}
+	return t, nil
+}
+
+// shortName returns the short form of a term attribute.
+func shortName(names []riName, attr string) string {
+	for _, n := range names {
+		if n.attr == attr {
+			return n.short
+		}
+	}
+	return strings.ReplaceAll(strings.ToLower(attr), " ", "")
+}
+
+// longName returns the attribute a short name v stands for, or an error
+// describing the valid names for what in term s.
+func longName(names []riName, v, kind, s, what string) (string, error) {
+	var valid []string
+	for _, n := range names {
+		if n.short == v {
+			return n.attr, nil
+		}
+		valid = append(valid, n.short)
+	}
+	return "", fmt.Errorf("%s %q: unknown %s %q (want %s)", kind, s, what, v, strings.Join(valid, ", "))
+}
+
+// A ReservedPrice is the price of an instance type under one reserved
+// instance offering.
+type ReservedPrice struct {
+	Term            RITerm
+	Upfront         float64 // one-time fee
+	Hourly          float64 // recurring hourly rate
+	EffectiveAnnual float64 // recurring cost of a year plus the upfront fee amortized over the term
+	Source          PriceSource
+}
+
+// ReservedPrice returns the price of in under term, if it is offered.
+func (in InstanceType) ReservedPrice(term RITerm) (ReservedPrice, bool) {
+	for _, rp := range in.Reserved {
+		if rp.Term == term {
+			return rp, true
+		}
+	}
+	return ReservedPrice{}, false
+}
+
+// BreakEven returns the utilization, as a fraction of the hours in a year,
+// above which rp costs less than running on demand at onDemandHourly. It is
+// greater than 1 if rp never does.
+func (rp ReservedPrice) BreakEven(onDemandHourly float64) float64 {
+	if onDemandHourly <= 0 {
+		return math.Inf(1)
+	}
+	return rp.EffectiveAnnual / (onDemandHourly * HoursPerYear)
+}
+
+// HoursPerYear is the number of hours annual prices are calculated over.
+const HoursPerYear = 24.0 * 365.0
+
+// reservedPrices returns the price of each reserved offering in an SKU's
+// terms, in AllRITerms order.
+func reservedPrices(terms map[string]Term) []ReservedPrice {
+	var prices []ReservedPrice
+	for _, term := range terms {
+		rp := ReservedPrice{
+			Term: RITerm{
+				Length:         term.TermAttributes.LeaseContractLength,
+				Class:          term.TermAttributes.OfferingClass,
+				PurchaseOption: term.TermAttributes.PurchaseOption,
+			},
+			Source: PriceSource{OfferTermCode: term.OfferTermCode, EffectiveDate: term.EffectiveDate},
+		}
+		for _, pd := range term.PriceDimensions {
+			f, _ := strconv.ParseFloat(pd.PricePerUnit["USD"], 64)
+			switch pd.Unit {
+			case "Hrs":
+				rp.Hourly = f
+				rp.Source.RateCode = pd.RateCode
+			case "Quantity":
+				rp.Upfront = f
+				rp.Source.UpfrontRateCode = pd.RateCode
+			}
+		}
+		rp.EffectiveAnnual = rp.Hourly * HoursPerYear
+		if years := rp.Term.Years(); years > 0 {
+			rp.EffectiveAnnual += rp.Upfront / float64(years)
+		}
+		prices = append(prices, rp)
+	}
+
+	order := make(map[RITerm]int)
+	for i, t := range AllRITerms {
+		order[t] = i
+	}
+	sort.Slice(prices, func(a, b int) bool {
+		oa, aok := order[prices[a].Term]
+		ob, bok := order[prices[b].Term]
+		if aok != bok {
+			return aok
+		}
+		if oa != ob {
+			return oa < ob
+		}
+		return prices[a].Term.String() < prices[b].Term.String()
+	})
+	return prices
+}
@@ -0,0 +1,173 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An SPTerm identifies a Savings Plans offering.
+type SPTerm struct {
+	Type           string // "ComputeSavingsPlans" or "EC2InstanceSavingsPlans"
+	Length         string // "1yr" or "3yr"
+	PurchaseOption string // "No Upfront", "Partial Upfront" or "All Upfront"
+}
+
+// AllSPTerms lists every Savings Plans offering, in the order
+// InstanceType.SavingsPlans is sorted in.
+var AllSPTerms []SPTerm
+
+func init() {
+	for _, typ := range spTypes {
+		for _, length := range riLengths {
+			for _, option := range riOptions {
+				AllSPTerms = append(AllSPTerms, SPTerm{typ.attr, length.attr, option.attr})
+			}
+		}
+	}
+}
+
+var spTypes = []riName{{"compute", "ComputeSavingsPlans"}, {"ec2", "EC2InstanceSavingsPlans"}}
+
+// String returns the short form of t parsed by ParseSPTerm, e.g.
+// "compute-1yr-none".
+func (t SPTerm) String() string {
+	return shortName(spTypes, t.Type) + "-" + shortName(riLengths, t.Length) + "-" + shortName(riOptions, t.PurchaseOption)
+}
+
+// ParseSPTerm parses the short form of a term, TYPE-LENGTH-OPTION, where TYPE
+// is compute or ec2, LENGTH 1yr or 3yr and OPTION none, partial or all.
+func ParseSPTerm(s string) (SPTerm, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return SPTerm{}, fmt.Errorf("savings plan term %q: want TYPE-LENGTH-OPTION, e.g. compute-1yr-none", s)
+	}
+
+	var (
+		t   SPTerm
+		err error
+	)
+	if t.Type, err = longName(spTypes, parts[0], "savings plan term", s, "type"); err != nil {
+		return SPTerm{}, err
+	}
+	if t.Length, err = longName(riLengths, parts[1], "savings plan term", s, "length"); err != nil {
+		return SPTerm{}, err
+	}
+	if t.PurchaseOption, err = longName(riOptions, parts[2], "savings plan term", s, "purchase option"); err != nil {
+		return SPTerm{}, err
+	}
+	return t, nil
+}
+
+// A SavingsPlanPrice is the rate an instance type is billed at when covered
+// by one Savings Plans offering.
+type SavingsPlanPrice struct {
+	Term            SPTerm
+	Hourly          float64 // discounted hourly rate, including any upfront payment
+	EffectiveAnnual float64
+}
+
+// SavingsPlanPrice returns the price of in under term, if it is offered.
+func (in InstanceType) SavingsPlanPrice(term SPTerm) (SavingsPlanPrice, bool) {
+	for _, sp := range in.SavingsPlans {
+		if sp.Term == term {
+			return sp, true
+		}
+	}
+	return SavingsPlanPrice{}, false
+}
+
+// loadSavingsPlanIndex returns the Savings Plans rate document of each
+// region, by region code.
+func loadSavingsPlanIndex(ctx context.Context, src Source, idx *PriceIndex) (map[string]DocRef, error) {
+	ref, err := idx.regionIndexRef(SavingsPlanOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	var spIdx SavingsPlanRegionIndex
+	if err := decodeDoc(ctx, src, ref, &spIdx); err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]DocRef)
+	for _, r := range spIdx.Regions {
+		refs[r.RegionCode] = DocRef{
+			Offer:  SavingsPlanOffer,
+			Region: r.RegionCode,
+			Path:   r.VersionURL,
+		}
+	}
+	return refs, nil
+}
+
+// loadSavingsPlans returns the Savings Plans prices of the SKUs in doc.
+func loadSavingsPlans(ctx context.Context, src Source, ref DocRef, doc *PriceDoc) (map[string][]SavingsPlanPrice, error) {
+	rc, err := src.Open(ctx, ref)
+	if err != nil {
+		return nil, &DocError{Ref: ref, Err: err}
+	}
+	defer rc.Close()
+
+	spDoc, err := decodeSavingsPlanDoc(rc, func(sku string) bool {
+		_, ok := doc.Products[sku]
+		return ok
+	})
+	if err != nil {
+		return nil, &DocError{Ref: ref, Err: err}
+	}
+	return savingsPlanPrices(spDoc), nil
+}
+
+// savingsPlanPrices joins the hourly rates in doc to the SKUs they discount,
+// each sorted in AllSPTerms order.
+func savingsPlanPrices(doc *SavingsPlanDoc) map[string][]SavingsPlanPrice {
+	terms := make(map[string]SPTerm)
+	for _, p := range doc.Products {
+		terms[p.Sku] = SPTerm{
+			Type:           p.ProductFamily,
+			Length:         p.Attributes.PurchaseTerm,
+			PurchaseOption: p.Attributes.PurchaseOption,
+		}
+	}
+
+	prices := make(map[string][]SavingsPlanPrice)
+	for _, term := range doc.Terms.SavingsPlan {
+		spTerm, ok := terms[term.Sku]
+		if !ok {
+			continue
+		}
+		for _, rate := range term.Rates {
+			if rate.Unit != "Hrs" {
+				continue
+			}
+			f, _ := strconv.ParseFloat(rate.DiscountedRate.Price, 64)
+			prices[rate.DiscountedSku] = append(prices[rate.DiscountedSku], SavingsPlanPrice{
+				Term:            spTerm,
+				Hourly:          f,
+				EffectiveAnnual: f * HoursPerYear,
+			})
+		}
+	}
+
+	order := make(map[SPTerm]int)
+	for i, t := range AllSPTerms {
+		order[t] = i
+	}
+	for _, sps := range prices {
+		sort.Slice(sps, func(a, b int) bool {
+			oa, aok := order[sps[a].Term]
+			ob, bok := order[sps[b].Term]
+			if aok != bok {
+				return aok
+			}
+			if oa != ob {
+				return oa < ob
+			}
+			return sps[a].Term.String() < sps[b].Term.String()
+		})
+	}
+	return prices
+}
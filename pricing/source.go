@@ -0,0 +1,112 @@
+package pricing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultBaseURL is the AWS Price List bulk API endpoint.
+	DefaultBaseURL = "https://pricing.us-east-1.amazonaws.com"
+
+	// IndexPath is the path of the offer index under the base URL.
+	IndexPath = "/offers/v1.0/aws/index.json"
+
+	// EC2Offer is the offer code of the EC2 price list.
+	EC2Offer = "AmazonEC2"
+
+	// SavingsPlanOffer is the offer code of the Compute and EC2 Instance
+	// Savings Plans rates.
+	SavingsPlanOffer = "AWSComputeSavingsPlan"
+)
+
+// A DocRef identifies one document in the offer file hierarchy: the
+// top-level offer index, an offer's region index or a region's price (or
+// Savings Plans rate) document.
+type DocRef struct {
+	Offer  string // empty for the offer index
+	Region string // empty for the offer and region indexes
+	Path   string // URL path relative to the base URL
+}
+
+// LocalName is the file name a document is saved under on disk, as read by
+// DirSource.
+func (ref DocRef) LocalName() string {
+	prefix := "ec2-price-"
+	if ref.Offer == SavingsPlanOffer {
+		prefix = "sp-price-"
+	}
+	switch {
+	case ref.Offer == "":
+		return "ec2-price-index.json"
+	case ref.Region == "":
+		return prefix + "region-index.json"
+	}
+	return prefix + ref.Region + ".json"
+}
+
+// A Source opens offer documents.
+type Source interface {
+	Open(ctx context.Context, ref DocRef) (io.ReadCloser, error)
+}
+
+// HTTPSource fetches documents from the pricing service.
+type HTTPSource struct {
+	BaseURL string       // defaults to DefaultBaseURL
+	Client  *http.Client // defaults to http.DefaultClient
+}
+
+func (s HTTPSource) Open(ctx context.Context, ref DocRef) (io.ReadCloser, error) {
+	r, err := get(ctx, s.Client, baseURL(s.BaseURL)+ref.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode != 200 {
+		return nil, statusError(r)
+	}
+	return r.Body, nil
+}
+
+// DirSource reads documents previously saved to Dir under their LocalName.
+type DirSource struct {
+	Dir string
+}
+
+func (s DirSource) Open(ctx context.Context, ref DocRef) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.Dir, ref.LocalName()))
+}
+
+func baseURL(u string) string {
+	if u == "" {
+		return DefaultBaseURL
+	}
+	return u
+}
+
+func get(ctx context.Context, client *http.Client, url string, hdr http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+func statusError(r *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(r.Body, 4096))
+	r.Body.Close()
+	return &StatusError{
+		URL:        r.Request.URL.String(),
+		StatusCode: r.StatusCode,
+		Body:       b,
+	}
+}
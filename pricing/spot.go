@@ -0,0 +1,133 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpotPrice summarizes the spot price history of an instance type in a
+// region.
+type SpotPrice struct {
+	Min    float64 // lowest price in any zone over the history
+	Median float64 // median of every price in the history
+	Latest float64 // most recent price in the cheapest zone
+	Zones  int     // availability zones with history
+}
+
+// SpotHistory is spot price history, as output by
+// "aws ec2 describe-spot-price-history".
+type SpotHistory struct {
+	SpotPriceHistory []SpotPriceRecord `json:"SpotPriceHistory"`
+}
+
+type SpotPriceRecord struct {
+	AvailabilityZone   string    `json:"AvailabilityZone"`
+	InstanceType       string    `json:"InstanceType"`
+	ProductDescription string    `json:"ProductDescription"`
+	SpotPrice          string    `json:"SpotPrice"`
+	Timestamp          time.Time `json:"Timestamp"`
+}
+
+// ReadSpotHistory reads spot price history in the JSON format output by
+// "aws ec2 describe-spot-price-history".
+func ReadSpotHistory(r io.Reader) (*SpotHistory, error) {
+	var h SpotHistory
+	if err := json.NewDecoder(r).Decode(&h); err != nil {
+		return nil, err
+	}
+	for _, rec := range h.SpotPriceHistory {
+		if _, err := strconv.ParseFloat(rec.SpotPrice, 64); err != nil {
+			return nil, fmt.Errorf("%s %s: bad spot price %q", rec.AvailabilityZone, rec.InstanceType, rec.SpotPrice)
+		}
+	}
+	return &h, nil
+}
+
+// spotDescriptions maps operating systems to the product descriptions spot
+// prices are published under.
+var spotDescriptions = map[string]string{
+	"Linux":   "Linux/UNIX",
+	"RHEL":    "Red Hat Enterprise Linux",
+	"SUSE":    "SUSE Linux",
+	"Windows": "Windows",
+}
+
+type spotKey struct {
+	region       string
+	instanceType string
+	description  string
+}
+
+// Apply sets the Spot price of each of instances that h has history for.
+// Only shared tenancy, running instances without pre-installed software have
+// spot prices, and instances with no Region get none, rather than a mix of
+// every region's history.
+func (h *SpotHistory) Apply(instances []InstanceType) {
+	type sample struct {
+		price float64
+		zone  string
+		at    time.Time
+	}
+	samples := make(map[spotKey][]sample)
+	for _, rec := range h.SpotPriceHistory {
+		price, _ := strconv.ParseFloat(rec.SpotPrice, 64)
+		desc := strings.TrimSuffix(rec.ProductDescription, " (Amazon VPC)")
+		s := sample{price, rec.AvailabilityZone, rec.Timestamp}
+		k := spotKey{zoneRegion(rec.AvailabilityZone), rec.InstanceType, desc}
+		samples[k] = append(samples[k], s)
+	}
+
+	for i, in := range instances {
+		desc, ok := spotDescriptions[in.OperatingSystem]
+		if !ok || in.Region == "" || in.Tenancy != TenancyShared || in.PreInstalledSW != "NA" || in.CapacityStatus != CapacityUsed {
+			continue
+		}
+		ss := samples[spotKey{in.Region, in.Name, desc}]
+		if len(ss) == 0 {
+			continue
+		}
+
+		prices := make([]float64, len(ss))
+		latest := make(map[string]sample)
+		for j, s := range ss {
+			prices[j] = s.price
+			if l, ok := latest[s.zone]; !ok || s.at.After(l.at) {
+				latest[s.zone] = s
+			}
+		}
+		sort.Float64s(prices)
+
+		sp := SpotPrice{
+			Min:    prices[0],
+			Median: median(prices),
+			Latest: -1,
+			Zones:  len(latest),
+		}
+		for _, s := range latest {
+			if sp.Latest < 0 || s.price < sp.Latest {
+				sp.Latest = s.price
+			}
+		}
+		instances[i].Spot = &sp
+	}
+}
+
+// zoneRegion returns the region of an availability zone, e.g. "us-east-1"
+// for "us-east-1a".
+func zoneRegion(zone string) string {
+	return strings.TrimRight(zone, "abcdefghijklmnopqrstuvwxyz")
+}
+
+// median returns the median of sorted values.
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,97 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpotHistory(t *testing.T) {
+	f, err := os.Open("testdata/spot-price-history.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	h, err := ReadSpotHistory(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := LoadRegions(context.Background(), DirSource{Dir: "testdata"}, nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Apply(instances)
+
+	got := make(map[string]*SpotPrice)
+	for _, in := range instances {
+		got[in.Name+" "+in.Region] = in.Spot
+	}
+	exp := map[string]*SpotPrice{
+		"m5.large eu-west-1":  {Min: 0.05, Median: 0.05, Latest: 0.05, Zones: 1},
+		"m5.large us-east-1":  {Min: 0.03, Median: 0.036, Latest: 0.036, Zones: 2},
+		"m7g.large us-east-1": nil,
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("mismatch:\ngot=%+v\nexp=%+v", got, exp)
+	}
+
+	instances, err = Load(context.Background(), DirSource{Dir: "testdata"}, "us-east-1", Options{OperatingSystem: "Windows"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Apply(instances)
+	if len(instances) != 1 || instances[0].Spot == nil || instances[0].Spot.Latest != 0.12 {
+		t.Errorf("windows mismatch: %+v", instances)
+	}
+}
+
+func TestReadSpotHistoryBadPrice(t *testing.T) {
+	in := `{"SpotPriceHistory": [{"AvailabilityZone": "us-east-1a", "InstanceType": "m5.large", "SpotPrice": "n/a"}]}`
+	if _, err := ReadSpotHistory(strings.NewReader(in)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSpotHistoryLoadDoc(t *testing.T) {
+	f, err := os.Open("testdata/spot-price-history.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	h, err := ReadSpotHistory(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := os.ReadFile("testdata/ec2-price-us-east-1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a regionCode there is no telling which region's history
+	// applies.
+	instances, err := LoadDoc(bytes.NewReader(doc), Options{Types: []string{"m5.large"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Apply(instances)
+	if len(instances) != 1 || instances[0].Region != "" || instances[0].Spot != nil {
+		t.Errorf("no regionCode mismatch: %+v", instances)
+	}
+
+	withCode := strings.ReplaceAll(string(doc), `"location": "US East (N. Virginia)"`, `"regionCode": "us-east-1", "location": "US East (N. Virginia)"`)
+	instances, err = LoadDoc(strings.NewReader(withCode), Options{Types: []string{"m5.large"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Apply(instances)
+	exp := &SpotPrice{Min: 0.03, Median: 0.036, Latest: 0.036, Zones: 2}
+	if len(instances) != 1 || instances[0].Region != "us-east-1" || !reflect.DeepEqual(instances[0].Spot, exp) {
+		t.Errorf("regionCode mismatch: %+v", instances)
+	}
+}
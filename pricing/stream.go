@@ -0,0 +1,245 @@
+package pricing
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// decodePriceDoc decodes a region price document from r, keeping only the
+// products keep returns true for and the terms that belong to them.
+// Everything else is skipped as it streams past rather than unmarshaled, so
+// memory use scales with what is kept instead of with the size of the region.
+func decodePriceDoc(r io.Reader, keep func(p *Product) bool) (*PriceDoc, error) {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+
+	doc := PriceDoc{
+		Products: make(map[string]Product),
+	}
+	doc.Terms.OnDemand = make(map[string]map[string]Term)
+	doc.Terms.Reserved = make(map[string]map[string]Term)
+
+	// Products come before terms in every document AWS publishes, but if
+	// that ever changes keep all terms until the products are known.
+	var productsDone bool
+	wanted := func(sku string) bool {
+		if !productsDone {
+			return true
+		}
+		_, ok := doc.Products[sku]
+		return ok
+	}
+
+	var skip json.RawMessage
+
+	err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "formatVersion":
+			return dec.Decode(&doc.FormatVersion)
+		case "disclaimer":
+			return dec.Decode(&doc.Disclaimer)
+		case "offerCode":
+			return dec.Decode(&doc.OfferCode)
+		case "version":
+			return dec.Decode(&doc.Version)
+		case "publicationDate":
+			return dec.Decode(&doc.PublicationDate)
+		case "products":
+			err := decodeObject(dec, func(sku string) error {
+				var p Product
+				if err := dec.Decode(&p); err != nil {
+					return fmt.Errorf("product %s: %w", sku, err)
+				}
+				if keep(&p) {
+					doc.Products[sku] = p
+				}
+				return nil
+			})
+			productsDone = true
+			return err
+		case "terms":
+			return decodeObject(dec, func(termType string) error {
+				var terms map[string]map[string]Term
+				switch termType {
+				case "OnDemand":
+					terms = doc.Terms.OnDemand
+				case "Reserved":
+					terms = doc.Terms.Reserved
+				default:
+					return dec.Decode(&skip)
+				}
+				return decodeObject(dec, func(sku string) error {
+					if !wanted(sku) {
+						return dec.Decode(&skip)
+					}
+					var skuTerms map[string]Term
+					if err := dec.Decode(&skuTerms); err != nil {
+						return fmt.Errorf("%s terms for %s: %w", termType, sku, err)
+					}
+					terms[sku] = skuTerms
+					return nil
+				})
+			})
+		}
+		return dec.Decode(&skip)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, terms := range []map[string]map[string]Term{doc.Terms.OnDemand, doc.Terms.Reserved} {
+		for sku := range terms {
+			if _, ok := doc.Products[sku]; !ok {
+				delete(terms, sku)
+			}
+		}
+	}
+
+	return &doc, nil
+}
+
+// decodeSavingsPlanDoc decodes a Savings Plans rate document from r,
+// keeping only the rates whose discounted SKU keep returns true for. Plans
+// left without rates are dropped.
+func decodeSavingsPlanDoc(r io.Reader, keep func(sku string) bool) (*SavingsPlanDoc, error) {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+
+	var doc SavingsPlanDoc
+	var skip json.RawMessage
+
+	err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "disclaimer":
+			return dec.Decode(&doc.Disclaimer)
+		case "publicationDate":
+			return dec.Decode(&doc.PublicationDate)
+		case "regionCode":
+			return dec.Decode(&doc.RegionCode)
+		case "version":
+			return dec.Decode(&doc.Version)
+		case "products":
+			return dec.Decode(&doc.Products)
+		case "terms":
+			return decodeObject(dec, func(termType string) error {
+				if termType != "savingsPlan" {
+					return dec.Decode(&skip)
+				}
+				return decodeArray(dec, func() error {
+					var term SavingsPlanTerm
+					if err := dec.Decode(&term); err != nil {
+						return fmt.Errorf("savings plan terms: %w", err)
+					}
+					rates := term.Rates[:0]
+					for _, rate := range term.Rates {
+						if keep(rate.DiscountedSku) {
+							rates = append(rates, rate)
+						}
+					}
+					if len(rates) > 0 {
+						term.Rates = rates
+						doc.Terms.SavingsPlan = append(doc.Terms.SavingsPlan, term)
+					}
+					return nil
+				})
+			})
+		}
+		return dec.Decode(&skip)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// regionAttributes returns the attributes of the first product in the price
+// document read from r that is located in region itself rather than in one
+// of its local or wavelength zones. It stops reading as soon as one is found.
+func regionAttributes(r io.Reader, region string) (*ProductAttributes, error) {
+	dec := json.NewDecoder(r)
+
+	var found *ProductAttributes
+	var skip json.RawMessage
+
+	err := decodeObject(dec, func(key string) error {
+		if key != "products" {
+			return dec.Decode(&skip)
+		}
+		return decodeObject(dec, func(sku string) error {
+			var p Product
+			if err := dec.Decode(&p); err != nil {
+				return fmt.Errorf("product %s: %w", sku, err)
+			}
+			attrs := &p.Attributes
+			if attrs.Location == "" {
+				return nil
+			}
+			if attrs.RegionCode == region || (attrs.RegionCode == "" && attrs.LocationType == "AWS Region") {
+				found = attrs
+				return errStop
+			}
+			return nil
+		})
+	})
+	if found != nil {
+		return found, nil
+	}
+	if err == nil {
+		err = errors.New("no product with a location found")
+	}
+	return nil, err
+}
+
+// errStop is returned from a decodeObject callback to stop decoding early.
+var errStop = errors.New("stop")
+
+// decodeObject reads a JSON object from dec, calling fn for each key. fn must
+// consume the key's value from dec.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// decodeArray reads a JSON array from dec, calling fn for each element. fn
+// must consume the element from dec.
+func decodeArray(dec *json.Decoder, fn func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDecodePriceDoc(t *testing.T) {
+	f, err := os.Open("testdata/ec2-price-us-east-1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	doc, err := decodePriceDoc(f, (&Options{}).keep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != "20260101000000" || doc.PublicationDate != "2026-01-01T00:00:00Z" {
+		t.Errorf("header mismatch: version=%q publicationDate=%q", doc.Version, doc.PublicationDate)
+	}
+
+	var skus []string
+	for sku := range doc.Products {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+	if strings.Join(skus, ",") != "SKU1,SKU2,SKU7" {
+		t.Errorf("products got=%v exp=[SKU1 SKU2 SKU7]", skus)
+	}
+
+	if _, ok := doc.Terms.OnDemand["SKU3"]; ok {
+		t.Errorf("OnDemand terms kept for filtered SKU3")
+	}
+	if len(doc.Terms.OnDemand["SKU1"]) != 1 || len(doc.Terms.Reserved["SKU1"]) != 2 {
+		t.Errorf("SKU1 terms mismatch: ondemand=%d reserved=%d", len(doc.Terms.OnDemand["SKU1"]), len(doc.Terms.Reserved["SKU1"]))
+	}
+}
+
+func TestDecodePriceDocTermsFirst(t *testing.T) {
+	in := `{
+  "terms": {
+    "OnDemand": {
+      "A": {"A.X": {"sku": "A"}},
+      "B": {"B.X": {"sku": "B"}}
+    },
+    "Savings": {"A": {}}
+  },
+  "products": {
+    "A": {"sku": "A", "attributes": {"instanceType": "m5.large"}},
+    "B": {"sku": "B", "attributes": {"instanceType": "m5.xlarge"}}
+  }
+}`
+
+	keep := func(p *Product) bool { return p.Attributes.InstanceType == "m5.large" }
+	doc, err := decodePriceDoc(strings.NewReader(in), keep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Products) != 1 || len(doc.Terms.OnDemand) != 1 || doc.Terms.OnDemand["A"] == nil {
+		t.Errorf("got products=%v ondemand=%v, exp only A", doc.Products, doc.Terms.OnDemand)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// requirements are the resources asked of the recommend command. A zero
+// minimum or maximum is not checked.
+type requirements struct {
+	minVCPU, maxVCPU float64
+	minMem, maxMem   float64 // GiB
+	minDisk, maxDisk float64 // total GB of instance storage
+	minNet, maxNet   float64 // Gbps
+	storage          string  // "", "local", "ssd" or "nvme"
+	arch             string  // "", "x86_64" or "arm64"
+	mfgs             []string
+}
+
+// arch returns the CPU architecture of an instance type, "arm64" for
+// Graviton and Apple silicon and "x86_64" for everything else. The price
+// list's processorArchitecture is usually just "64-bit", so the processor
+// decides.
+func arch(in pricing.InstanceType) string {
+	a := in.Attributes
+	if in.CPUMfgr == pricing.CPUAWS || strings.HasPrefix(a.PhysicalProcessor, "Apple") ||
+		strings.Contains(strings.ToLower(a.ProcessorArchitecture), "arm") {
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+// validate checks that the requirements are consistent.
+func (r *requirements) validate() error {
+	for _, b := range []struct {
+		name     string
+		min, max float64
+	}{
+		{"vcpu", r.minVCPU, r.maxVCPU},
+		{"mem", r.minMem, r.maxMem},
+		{"disk", r.minDisk, r.maxDisk},
+		{"net", r.minNet, r.maxNet},
+	} {
+		if b.min < 0 || b.max < 0 {
+			return fmt.Errorf("-min-%s and -max-%s cannot be negative", b.name, b.name)
+		}
+		if b.max != 0 && b.min > b.max {
+			return fmt.Errorf("-min-%s %v is more than -max-%s %v", b.name, b.min, b.name, b.max)
+		}
+	}
+	switch r.storage {
+	case "", "local", "ssd", "nvme":
+	default:
+		return fmt.Errorf("unknown -storage %q, want local, ssd or nvme", r.storage)
+	}
+	switch r.arch {
+	case "", "x86_64", "arm64":
+	default:
+		return fmt.Errorf("unknown -arch %q, want x86_64 or arm64", r.arch)
+	}
+	for _, m := range r.mfgs {
+		switch m {
+		case "int", "amd", "arm":
+		default:
+			return fmt.Errorf("unknown -mfg %q, want int, amd or arm", m)
+		}
+	}
+	return nil
+}
+
+// match reports whether in meets every requirement.
+func (r *requirements) match(in pricing.InstanceType) bool {
+	within := func(v, min, max float64) bool {
+		return v >= min && (max == 0 || v <= max)
+	}
+	if !within(float64(in.VCPU), r.minVCPU, r.maxVCPU) ||
+		!within(in.Memory, r.minMem, r.maxMem) ||
+		!within(float64(in.Disk.Count*in.Disk.PerDiskGB), r.minDisk, r.maxDisk) ||
+		!within(in.NetworkPerf.CapGb, r.minNet, r.maxNet) {
+		return false
+	}
+
+	switch r.storage {
+	case "local":
+		if in.Disk.Count == 0 {
+			return false
+		}
+	case "ssd":
+		if !in.Disk.SSD {
+			return false
+		}
+	case "nvme":
+		if !in.Disk.NVMe {
+			return false
+		}
+	}
+	if r.arch != "" && arch(in) != r.arch {
+		return false
+	}
+	if len(r.mfgs) > 0 {
+		found := false
+		for _, m := range r.mfgs {
+			found = found || m == in.CPUMfgr.String()
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// headroom describes how much more than each requested minimum in has, e.g.
+// "vcpu +4 (+33%), mem +16GiB (+33%)", or "exact" if it has no more than
+// asked for.
+func (r *requirements) headroom(in pricing.InstanceType) string {
+	var parts []string
+	add := func(name string, have, want float64, unit string) {
+		if want == 0 || have <= want {
+			return
+		}
+		parts = append(parts, fmt.Sprintf("%s +%s%s (+%.0f%%)", name, trimFloat(have-want), unit, (have-want)/want*100))
+	}
+	add("vcpu", float64(in.VCPU), r.minVCPU, "")
+	add("mem", in.Memory, r.minMem, "GiB")
+	add("disk", float64(in.Disk.Count*in.Disk.PerDiskGB), r.minDisk, "GB")
+	add("net", in.NetworkPerf.CapGb, r.minNet, "Gb")
+	if in.NetworkPerf.Bursting && r.minNet > 0 {
+		parts = append(parts, "net is burstable")
+	}
+	if len(parts) == 0 {
+		return "exact"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// trimFloat formats v with up to two decimal places and no trailing zeros.
+func trimFloat(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// priceFields are the fields recommend can rank by.
+var priceFields = []string{"hourly", "annual", "reserved", "spot"}
+
+// recommendColumns returns the columns recommend lists for r, ranked by
+// the price field by.
+func recommendColumns(r requirements, by string, multiRegion bool) []column {
+	var cols []column
+	if multiRegion {
+		cols = append(cols, namedColumns["region"])
+	}
+	for _, name := range []string{"type", "vcpu", "mem", "disk", "net"} {
+		cols = append(cols, namedColumns[name])
+	}
+	metric := fields[by]
+	cols = append(cols,
+		column{
+			name:    by,
+			numeric: true,
+			text:    func(in pricing.InstanceType) string { return currency(metric.num(in)) },
+			csv:     func(in pricing.InstanceType) string { return toS(metric.num(in)) },
+			json:    func(in pricing.InstanceType) interface{} { return metric.num(in) },
+		},
+		strColumn("headroom", r.headroom),
+	)
+	return cols
+}
+
+// recommend implements the recommend command, which lists the cheapest
+// instance types that meet a set of resource requirements.
+func recommend(args []string) {
+	var r requirements
+	fs := flag.NewFlagSet("recommend", flag.ExitOnError)
+	fs.Float64Var(&r.minVCPU, "min-vcpu", 0, "Minimum vCPUs")
+	fs.Float64Var(&r.maxVCPU, "max-vcpu", 0, "Maximum vCPUs")
+	fs.Float64Var(&r.minMem, "min-mem", 0, "Minimum memory in GiB")
+	fs.Float64Var(&r.maxMem, "max-mem", 0, "Maximum memory in GiB")
+	fs.Float64Var(&r.minDisk, "min-disk", 0, "Minimum total instance storage in GB")
+	fs.Float64Var(&r.maxDisk, "max-disk", 0, "Maximum total instance storage in GB")
+	fs.Float64Var(&r.minNet, "min-net", 0, "Minimum network bandwidth in Gbps")
+	fs.Float64Var(&r.maxNet, "max-net", 0, "Maximum network bandwidth in Gbps")
+	fs.StringVar(&r.storage, "storage", "", "Required instance storage: local, ssd or nvme")
+	fs.StringVar(&r.arch, "arch", "", "Required CPU architecture: x86_64 or arm64")
+	mfg := fs.String("mfg", "", "Comma separated CPU manufacturers to allow: int, amd or arm")
+	by := fs.String("by", "annual", "Price to rank by, cheapest first: "+strings.Join(priceFields, ", "))
+	count := fs.Int("n", 5, "Number of instance types to list (0 for all)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] recommend [recommend flags] [type pattern...]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *mfg != "" {
+		for _, m := range strings.Split(*mfg, ",") {
+			r.mfgs = append(r.mfgs, strings.TrimSpace(m))
+		}
+	}
+	checkErr(r.validate(), "recommend")
+	if !slices.Contains(priceFields, *by) {
+		log.Fatalf("unknown -by %q, want one of %s", *by, strings.Join(priceFields, ", "))
+	}
+	if *by == "spot" && *spotHistory == "" {
+		log.Fatal("-by spot needs -spot-history")
+	}
+	metric := fields[*by]
+	render := tableFormats[*outFormat]
+	if render == nil && *outFormat != "json" && *outFormat != "ndjson" {
+		log.Fatalf("recommend cannot write -format %s", *outFormat)
+	}
+	opts := loadOptions()
+	opts.Types = fs.Args()
+	keep := mustParseWhere(*where, &opts)
+	instances := filterWhere(loadInstances(opts), keep)
+
+	matches := instances[:0]
+	for _, in := range instances {
+		if r.match(in) && !math.IsNaN(metric.num(in)) {
+			matches = append(matches, in)
+		}
+	}
+	sortInstances(matches, []sortKey{{name: *by, f: metric}})
+	if *count > 0 && len(matches) > *count {
+		matches = matches[:*count]
+	}
+	if len(matches) == 0 {
+		log.Fatal("no instance types meet the requirements")
+	}
+
+	cols := recommendColumns(r, *by, len(regionList()) != 1)
+	if render == nil {
+		checkErr(writeJSON(os.Stdout, cols, matches, nil, *outFormat == "ndjson"), "Write json")
+		return
+	}
+	checkErr(render(os.Stdout, newTable(cols, matches, *outFormat == "csv")), "Write "+*outFormat)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestRequirements(t *testing.T) {
+	m5 := pricing.InstanceType{Name: "m5.xlarge", VCPU: 4, Memory: 16, CPUMfgr: pricing.CPUIntel, NetworkPerf: pricing.NetworkPerf{CapGb: 10, Bursting: true}}
+	i4g := pricing.InstanceType{Name: "i4g.4xlarge", VCPU: 16, Memory: 128, CPUMfgr: pricing.CPUAWS,
+		Disk: pricing.Disk{Count: 1, PerDiskGB: 3750, SSD: true, NVMe: true}, NetworkPerf: pricing.NetworkPerf{CapGb: 25}}
+
+	tests := []struct {
+		name     string
+		r        requirements
+		m5, i4g  bool
+		headroom string // of i4g
+	}{
+		{"none", requirements{}, true, true, "exact"},
+		{"min vcpu", requirements{minVCPU: 12, minMem: 48}, false, true, "vcpu +4 (+33%), mem +80GiB (+167%)"},
+		{"max mem", requirements{maxMem: 32}, true, false, "exact"},
+		{"nvme", requirements{storage: "nvme", minNet: 10}, false, true, "net +15Gb (+150%)"},
+		{"local", requirements{storage: "local", minDisk: 3000}, false, true, "disk +750GB (+25%)"},
+		{"arch", requirements{arch: "x86_64"}, true, false, "exact"},
+		{"mfg", requirements{mfgs: []string{"amd", "arm"}}, false, true, "exact"},
+	}
+	for _, tc := range tests {
+		if err := tc.r.validate(); err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+		}
+		if got := tc.r.match(m5); got != tc.m5 {
+			t.Errorf("%s: match(m5) = %t, want %t", tc.name, got, tc.m5)
+		}
+		if got := tc.r.match(i4g); got != tc.i4g {
+			t.Errorf("%s: match(i4g) = %t, want %t", tc.name, got, tc.i4g)
+		}
+		if got := tc.r.headroom(i4g); got != tc.headroom {
+			t.Errorf("%s: headroom = %q, want %q", tc.name, got, tc.headroom)
+		}
+	}
+
+	// mac2 runs on Apple silicon, which is no manufacturer the price list
+	// names.
+	mac2 := pricing.InstanceType{Name: "mac2.metal", VCPU: 12, Memory: 16,
+		Attributes: pricing.ProductAttributes{PhysicalProcessor: "Apple M1 chip", ProcessorArchitecture: "64-bit"}}
+	if got := arch(mac2); got != "arm64" {
+		t.Errorf("arch(mac2) = %q, want arm64", got)
+	}
+	if (&requirements{arch: "x86_64"}).match(mac2) || !(&requirements{arch: "arm64"}).match(mac2) {
+		t.Error("mac2 matched as x86_64")
+	}
+
+	r := requirements{minNet: 5}
+	if got, exp := r.headroom(m5), "net +5Gb (+100%), net is burstable"; got != exp {
+		t.Errorf("burstable headroom = %q, want %q", got, exp)
+	}
+
+	for _, r := range []requirements{
+		{minVCPU: 8, maxVCPU: 4},
+		{minMem: -1},
+		{storage: "tape"},
+		{arch: "riscv"},
+		{mfgs: []string{"ibm"}},
+	} {
+		if err := r.validate(); err == nil {
+			t.Errorf("validate(%+v) = nil, want an error", r)
+		}
+	}
+}
+
+func TestRecommendColumns(t *testing.T) {
+	r := requirements{minVCPU: 2}
+	instances := []pricing.InstanceType{
+		{Name: "m7g.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.0816, OnDemandAnnual: 714.816},
+		{Name: "u7in-24tb.224xlarge", Region: "eu-west-1", VCPU: 896, Memory: 24576, Hourly: 292.24, OnDemandAnnual: 2560022.4},
+	}
+
+	var buf bytes.Buffer
+	if err := renderCol(&buf, newTable(recommendColumns(r, "annual", true), instances, false)); err != nil {
+		t.Fatal(err)
+	}
+	exp := `   region                type vcpu     mem disk net        annual            headroom
+us-east-1           m7g.large    2     8.0  EBS 0.0       $714.82               exact
+eu-west-1 u7in-24tb.224xlarge  896 24576.0  EBS 0.0 $2,560,022.40 vcpu +894 (+44700%)
+`
+	if got := buf.String(); got != exp {
+		t.Errorf("got:\n%s\nexp:\n%s", got, exp)
+	}
+
+	buf.Reset()
+	if err := renderCSV(&buf, newTable(recommendColumns(r, "annual", false), instances[:1], true)); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "type,vcpu,mem,disk,net,annual,headroom\nm7g.large,2,8.000,EBS,0.0,714.816,exact\n"; buf.String() != exp {
+		t.Errorf("csv got:\n%s\nexp:\n%s", buf.String(), exp)
+	}
+}
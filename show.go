@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// showTypes implements the show command, which prints everything the price
+// list says about instance types.
+func showTypes(args []string) {
+	fs := flag.NewFlagSet("show", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] show type...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	opts := loadOptions()
+	opts.Types = fs.Args()
+	loaded := loadInstances(opts)
+
+	first := true
+	for _, name := range fs.Args() {
+		found := false
+		for _, in := range loaded {
+			if in.Name != name {
+				continue
+			}
+			if !first {
+				fmt.Println()
+			}
+			first = false
+			found = true
+			showInstance(os.Stdout, in)
+		}
+		if !found {
+			log.Fatalf("no price for %s", name)
+		}
+	}
+}
+
+// showInstance writes in's SKUs, product attributes, family and terms.
+func showInstance(w io.Writer, in pricing.InstanceType) {
+	fmt.Fprintf(w, "%s", in.Name)
+	if in.Region != "" {
+		fmt.Fprintf(w, " in %s", in.Region)
+	}
+	fmt.Fprintf(w, "\nsku: %s\n", in.SKU)
+	if len(in.OtherSKUs) > 0 {
+		fmt.Fprintf(w, "ambiguous, also matched: %s\n", strings.Join(in.OtherSKUs, ", "))
+	}
+	fmt.Fprintf(w, "version: %s\npublished: %s\n", in.Version, in.PublicationDate)
+
+	fmt.Fprintf(w, "\nattributes:\n")
+	attrs := reflect.ValueOf(in.Attributes)
+	for i := range attrs.NumField() {
+		name, _, _ := strings.Cut(attrs.Type().Field(i).Tag.Get("json"), ",")
+		showField(w, name, attrs.Field(i).String())
+	}
+
+	fmt.Fprintf(w, "\nfamily:\n")
+	if info := familyInfo(in.Family); info.Name != "" {
+		showField(w, "name", info.Name)
+		showField(w, "year", toS(info.Year))
+		showField(w, "prefix", info.Prefix.String())
+		showField(w, "flags", info.Flags.String())
+		showField(w, "url", info.URL)
+	} else {
+		fmt.Fprintf(w, "  %s is not in the family table\n", in.Family)
+	}
+
+	fmt.Fprintf(w, "\non-demand terms:\n")
+	showTerms(w, in.OnDemandTerms, false)
+	fmt.Fprintf(w, "\nreserved terms:\n")
+	showTerms(w, in.ReservedTerms, true)
+}
+
+// showField writes one name and value line, with "-" for an empty value.
+func showField(w io.Writer, name, v string) {
+	if v == "" {
+		v = "-"
+	}
+	fmt.Fprintf(w, "  %-28s %s\n", name, v)
+}
+
+// showTerms writes terms in key order, each followed by its price
+// dimensions.
+func showTerms(w io.Writer, terms map[string]pricing.Term, reserved bool) {
+	if len(terms) == 0 {
+		fmt.Fprintf(w, "  none\n")
+		return
+	}
+
+	keys := make([]string, 0, len(terms))
+	for k := range terms {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		t := terms[k]
+		fmt.Fprintf(w, "  %s: sku %s, offer term code %s, effective %s", k, t.Sku, t.OfferTermCode, t.EffectiveDate)
+		if reserved {
+			term := pricing.RITerm{
+				Length:         t.TermAttributes.LeaseContractLength,
+				Class:          t.TermAttributes.OfferingClass,
+				PurchaseOption: t.TermAttributes.PurchaseOption,
+			}
+			fmt.Fprintf(w, ", %s (%s %s %s)", term, term.Length, term.Class, term.PurchaseOption)
+		}
+		fmt.Fprintln(w)
+
+		rates := make([]string, 0, len(t.PriceDimensions))
+		for rc := range t.PriceDimensions {
+			rates = append(rates, rc)
+		}
+		sort.Strings(rates)
+		for _, rc := range rates {
+			pd := t.PriceDimensions[rc]
+			fmt.Fprintf(w, "    %s: %s USD per %s", pd.RateCode, pd.PricePerUnit["USD"], pd.Unit)
+			if pd.Description != "" {
+				fmt.Fprintf(w, ", %s", pd.Description)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestShowInstance(t *testing.T) {
+	in := pricing.InstanceType{
+		Name:            "m7g.large",
+		Region:          "us-east-1",
+		Family:          "m7g",
+		SKU:             "SKU2",
+		OtherSKUs:       []string{"SKU7"},
+		PublicationDate: "2026-01-01T00:00:00Z",
+		Attributes:      pricing.ProductAttributes{InstanceType: "m7g.large", PhysicalProcessor: "AWS Graviton3"},
+		OnDemandTerms: map[string]pricing.Term{
+			"SKU2.JRTCKXETXF": {
+				Sku:           "SKU2",
+				OfferTermCode: "JRTCKXETXF",
+				EffectiveDate: "2026-01-01T00:00:00Z",
+				PriceDimensions: map[string]pricing.PriceDimension{
+					"SKU2.JRTCKXETXF.6YS6EN2CT7": {
+						RateCode:     "SKU2.JRTCKXETXF.6YS6EN2CT7",
+						PricePerUnit: map[string]string{"USD": "0.0816000000"},
+						Unit:         "Hrs",
+						Description:  "$0.0816 per On Demand Linux m7g.large Instance Hour",
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	showInstance(&buf, in)
+	for _, exp := range []string{
+		"m7g.large in us-east-1\nsku: SKU2\nambiguous, also matched: SKU7\n",
+		"  instanceType                 m7g.large\n",
+		"  clockSpeed                   -\n",
+		"  physicalProcessor            AWS Graviton3\n",
+		"  flags                        graviton\n",
+		"  url                          https://aws.amazon.com/blogs/aws/new-graviton3-based-",
+		"  SKU2.JRTCKXETXF: sku SKU2, offer term code JRTCKXETXF, effective 2026-01-01T00:00:00Z\n",
+		"    SKU2.JRTCKXETXF.6YS6EN2CT7: 0.0816000000 USD per Hrs, $0.0816 per On Demand Linux m7g.large Instance Hour\n",
+		"reserved terms:\n  none\n",
+	} {
+		if !strings.Contains(buf.String(), exp) {
+			t.Errorf("missing %q in:\n%s", exp, buf.String())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A sortKey is one key of -sort.
+type sortKey struct {
+	name string
+	desc bool
+	f    field
+}
+
+// parseSortKeys parses a comma separated list of field or column names,
+// each optionally prefixed with - to sort in descending order.
+func parseSortKeys(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		k := sortKey{name: s}
+		if strings.HasPrefix(s, "-") {
+			k.name = s[1:]
+			k.desc = true
+		}
+		f, ok := lookupField(k.name)
+		if !ok {
+			return nil, fmt.Errorf("unknown sort key %q (fields: %s, or a column)", k.name, strings.Join(sortedFieldNames(), ", "))
+		}
+		k.f = f
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// sortInstances sorts instances by keys, then by name. The sort is stable,
+// so types with the same name stay in region order. Missing prices sort
+// last in either direction.
+func sortInstances(instances []pricing.InstanceType, keys []sortKey) {
+	sort.SliceStable(instances, func(a, b int) bool {
+		for _, k := range keys {
+			if c := k.compare(instances[a], instances[b]); c != 0 {
+				return c < 0
+			}
+		}
+		return instances[a].Name < instances[b].Name
+	})
+}
+
+// compare returns -1 if a sorts before b, 1 if after and 0 if they are
+// equal under k.
+func (k sortKey) compare(a, b pricing.InstanceType) int {
+	var c int
+	switch k.f.kind {
+	case numField:
+		x, y := k.f.num(a), k.f.num(b)
+		switch xNaN, yNaN := math.IsNaN(x), math.IsNaN(y); {
+		case xNaN && yNaN:
+			return 0
+		case xNaN:
+			return 1
+		case yNaN:
+			return -1
+		}
+		c = cmp.Compare(x, y)
+	case strField:
+		c = strings.Compare(k.f.str(a), k.f.str(b))
+	case boolField:
+		x, y := k.f.bool(a), k.f.bool(b)
+		if x != y {
+			c = 1
+			if y {
+				c = -1
+			}
+		}
+	}
+	if k.desc {
+		return -c
+	}
+	return c
+}
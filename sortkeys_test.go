@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestSortInstances(t *testing.T) {
+	instances := []pricing.InstanceType{
+		{Name: "c5.large", VCPU: 2, Memory: 4, Hourly: 0.085},
+		{Name: "m5.xlarge", VCPU: 4, Memory: 16, Hourly: 0.192, ReservedAnnual: 1200},
+		{Name: "m5.large", Region: "us-east-1", VCPU: 2, Memory: 8, Hourly: 0.096, ReservedAnnual: 600},
+		{Name: "m5.large", Region: "eu-west-1", VCPU: 2, Memory: 8, Hourly: 0.107},
+		{Name: "a1.large", VCPU: 2, Memory: 4, Hourly: 0.051},
+	}
+
+	tests := []struct {
+		spec string
+		exp  []string
+	}{
+		{"annual", []string{"a1.large", "c5.large", "m5.large/us-east-1", "m5.large/eu-west-1", "m5.xlarge"}},
+		{"mem,-vcpu", []string{"a1.large", "c5.large", "m5.large/us-east-1", "m5.large/eu-west-1", "m5.xlarge"}},
+		{"-vcpu,-hourly", []string{"m5.xlarge", "m5.large/eu-west-1", "m5.large/us-east-1", "c5.large", "a1.large"}},
+		{"-reserved", []string{"m5.xlarge", "m5.large/us-east-1", "a1.large", "c5.large", "m5.large/eu-west-1"}},
+		{"-annual-reserved", []string{"m5.xlarge", "m5.large/us-east-1", "a1.large", "c5.large", "m5.large/eu-west-1"}},
+		{"vcpu-hr", []string{"a1.large", "c5.large", "m5.large/us-east-1", "m5.xlarge", "m5.large/eu-west-1"}},
+		{"-mem/vcpu,hourly", []string{"m5.large/us-east-1", "m5.large/eu-west-1", "m5.xlarge", "a1.large", "c5.large"}},
+	}
+	for _, test := range tests {
+		keys, err := parseSortKeys(test.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted := append([]pricing.InstanceType(nil), instances...)
+		sortInstances(sorted, keys)
+
+		var got []string
+		for _, in := range sorted {
+			name := in.Name
+			if in.Region != "" {
+				name += "/" + in.Region
+			}
+			got = append(got, name)
+		}
+		if !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("-sort %s:\ngot=%q\nexp=%q", test.spec, got, test.exp)
+		}
+	}
+
+	if _, err := parseSortKeys("mem,-foo"); err == nil {
+		t.Error("unknown key: expected error")
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A table is the listing as text cells, ready to be rendered in one of the
+// tableFormats.
+type table struct {
+	cols []column
+	rows [][]string
+}
+
+// newTable returns the cells of cols for instances. csv selects the csv
+// text of each column rather than its display text.
+func newTable(cols []column, instances []pricing.InstanceType, csv bool) *table {
+	t := &table{cols: cols}
+	for _, in := range instances {
+		row := make([]string, len(cols))
+		for i, c := range cols {
+			if csv {
+				row[i] = c.csvText(in)
+			} else {
+				row[i] = c.text(in)
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// widths returns the width of each column: its widest cell or header.
+func (t *table) widths() []int {
+	widths := make([]int, len(t.cols))
+	for i, c := range t.cols {
+		widths[i] = utf8.RuneCountInString(c.name)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	return widths
+}
+
+// tableFormats are the -format values rendered from a table.
+var tableFormats = map[string]func(w io.Writer, t *table) error{
+	"col":      renderCol,
+	"csv":      renderCSV,
+	"tsv":      renderTSV,
+	"markdown": renderMarkdown,
+	"html":     renderHTML,
+}
+
+// renderCol writes right aligned columns separated by a space.
+func renderCol(w io.Writer, t *table) error {
+	widths := t.widths()
+	line := func(cells []string) error {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = pad(cell, widths[i], true)
+		}
+		_, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(padded, " "), " "))
+		return err
+	}
+
+	if err := line(t.header()); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderCSV(w io.Writer, t *table) error {
+	cw := csv.NewWriter(w)
+	cw.Write(t.header())
+	for _, row := range t.rows {
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+func renderTSV(w io.Writer, t *table) error {
+	line := func(cells []string) error {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = tsvReplacer.Replace(cell)
+		}
+		_, err := fmt.Fprintln(w, strings.Join(escaped, "\t"))
+		return err
+	}
+
+	if err := line(t.header()); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// renderMarkdown writes a GitHub flavored markdown table, with numeric
+// columns right aligned.
+func renderMarkdown(w io.Writer, t *table) error {
+	header := t.header()
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make([]string, len(row))
+		for j, cell := range row {
+			rows[i][j] = markdownReplacer.Replace(cell)
+		}
+	}
+	escaped := &table{cols: t.cols, rows: rows}
+	widths := escaped.widths()
+
+	line := func(cells []string) error {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = pad(cell, widths[i], t.cols[i].numeric)
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(padded, " | "))
+		return err
+	}
+
+	if err := line(header); err != nil {
+		return err
+	}
+	rule := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		rule[i] = strings.Repeat("-", widths[i]+2)
+		if c.numeric {
+			rule[i] = rule[i][1:] + ":"
+		}
+	}
+	if _, err := fmt.Fprintf(w, "|%s|\n", strings.Join(rule, "|")); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderHTML writes a standalone HTML page with the table. Clicking a
+// column header sorts by it.
+func renderHTML(w io.Writer, t *table) error {
+	type htmlColumn struct {
+		Name    string
+		Numeric bool
+	}
+	data := struct {
+		Cols []htmlColumn
+		Rows [][]string
+	}{Rows: t.rows}
+	for _, c := range t.cols {
+		data.Cols = append(data.Cols, htmlColumn{c.name, c.numeric})
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>EC2 instance prices</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { padding: 2px 8px; border-bottom: 1px solid #ddd; }
+th { cursor: pointer; text-align: left; background: #f4f4f4; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+th[aria-sort=ascending]::after { content: " \25b2"; }
+th[aria-sort=descending]::after { content: " \25bc"; }
+</style>
+</head>
+<body>
+<table>
+<thead>
+<tr>{{range .Cols}}<th{{if .Numeric}} data-numeric{{end}}>{{.Name}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- $cols := .Cols}}
+{{range .Rows}}<tr>{{range $i, $cell := .}}<td{{if (index $cols $i).Numeric}} class="num"{{end}}>{{$cell}}</td>{{end}}</tr>
+{{end -}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("th").forEach(function(th, col) {
+  th.addEventListener("click", function() {
+    var desc = th.getAttribute("aria-sort") === "ascending";
+    document.querySelectorAll("th").forEach(function(h) { h.removeAttribute("aria-sort"); });
+    th.setAttribute("aria-sort", desc ? "descending" : "ascending");
+    var numeric = th.hasAttribute("data-numeric");
+    var tbody = document.querySelector("tbody");
+    var rows = Array.from(tbody.rows);
+    rows.sort(function(a, b) {
+      var x = a.cells[col].textContent, y = b.cells[col].textContent;
+      if (numeric) {
+        x = parseFloat(x); y = parseFloat(y);
+        if (isNaN(x) || isNaN(y)) { return isNaN(x) - isNaN(y); }
+      }
+      var c = x < y ? -1 : x > y ? 1 : 0;
+      return desc ? -c : c;
+    });
+    rows.forEach(function(r) { tbody.appendChild(r); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+func (t *table) header() []string {
+	header := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		header[i] = c.name
+	}
+	return header
+}
+
+// pad pads s with spaces to width, on the left if right is set.
+func pad(s string, width int, right bool) string {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	if right {
+		return strings.Repeat(" ", n) + s
+	}
+	return s + strings.Repeat(" ", n)
+}
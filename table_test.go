@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func testTable(t *testing.T) *table {
+	var cols []column
+	for _, name := range []string{"type", "vcpu", "disk"} {
+		c, err := columnByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, c)
+	}
+	instances := []pricing.InstanceType{
+		{Name: "u7in-24tb.224xlarge", VCPU: 896},
+		{Name: "a|b<i>", VCPU: 2, Disk: pricing.Disk{Count: 2, PerDiskGB: 900, SSD: true}},
+	}
+	return newTable(cols, instances, false)
+}
+
+func TestRenderTable(t *testing.T) {
+	tests := []struct {
+		format string
+		exp    string
+	}{
+		{"col", `
+               type vcpu    disk
+u7in-24tb.224xlarge  896     EBS
+             a|b<i>    2 1TB-SSD
+`},
+		{"tsv", `
+type	vcpu	disk
+u7in-24tb.224xlarge	896	EBS
+a|b<i>	2	1TB-SSD
+`},
+		{"markdown", `
+| type                | vcpu | disk    |
+|---------------------|-----:|---------|
+| u7in-24tb.224xlarge |  896 | EBS     |
+| a\|b<i>             |    2 | 1TB-SSD |
+`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := tableFormats[test.format](&buf, testTable(t)); err != nil {
+			t.Fatal(err)
+		}
+		if exp := strings.TrimPrefix(test.exp, "\n"); buf.String() != exp {
+			t.Errorf("%s mismatch:\ngot:\n%s\nexp:\n%s", test.format, buf.String(), exp)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := renderHTML(&buf, testTable(t)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<th data-numeric>vcpu</th>", "<td>a|b&lt;i&gt;</td>", `<td class="num">896</td>`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("html missing %s:\n%s", want, buf.String())
+		}
+	}
+}
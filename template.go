@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// templateFuncs returns the functions available to -template besides the
+// text/template builtins, with dominatedBy looking types up in doms.
+func templateFuncs(doms dominators) template.FuncMap {
+	return template.FuncMap{
+		"currency":  currency,
+		"shortType": shortType,
+		"family":    familyInfo,
+
+		"dominatedBy": doms.dominatedBy,
+	}
+}
+
+// currency formats a dollar amount with thousands separators, to the cent,
+// or to a hundredth of a cent below a dollar so hourly prices keep their
+// precision: "$1,234.57", "$0.0816".
+func currency(v float64) string {
+	if v < 0 {
+		return "-" + currency(-v)
+	}
+	if v < 1 {
+		return "$" + strconv.FormatFloat(v, 'f', 4, 64)
+	}
+
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	whole, cents, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return "$" + b.String() + "." + cents
+}
+
+// familyInfo returns the instanceTypes entry of a family or instance type,
+// or the zero InstanceTypeInfo if there is none.
+func familyInfo(name string) InstanceTypeInfo {
+	family, _, _ := strings.Cut(name, ".")
+	for _, it := range instanceTypes {
+		if it.Name == family {
+			return it
+		}
+	}
+	return InstanceTypeInfo{}
+}
+
+// parseTemplate returns the -template or -template-file template.
+func parseTemplate() *template.Template {
+	text := *templateText
+	switch {
+	case text != "" && *templateFile != "":
+		log.Fatal("-template cannot be combined with -template-file")
+	case *templateFile != "":
+		b, err := os.ReadFile(*templateFile)
+		checkErr(err, "Read template")
+		text = string(b)
+	case text == "":
+		log.Fatal("-format template needs -template or -template-file")
+	}
+
+	tmpl, err := template.New("template").Funcs(templateFuncs(nil)).Parse(text)
+	checkErr(err, "Parse template")
+	return tmpl
+}
+
+// renderTemplate executes tmpl with instances as its data, and doms for
+// dominatedBy.
+func renderTemplate(w io.Writer, tmpl *template.Template, instances []pricing.InstanceType, doms dominators) error {
+	if err := tmpl.Funcs(templateFuncs(doms)).Execute(w, instances); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+	return nil
+}
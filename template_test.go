@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		v   float64
+		exp string
+	}{
+		{0, "$0.0000"},
+		{0.0816, "$0.0816"},
+		{1, "$1.00"},
+		{714.816, "$714.82"},
+		{1234.5, "$1,234.50"},
+		{1234567.891, "$1,234,567.89"},
+		{-2500, "-$2,500.00"},
+	}
+	for _, tc := range tests {
+		if got := currency(tc.v); got != tc.exp {
+			t.Errorf("currency(%v) = %q, want %q", tc.v, got, tc.exp)
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(templateFuncs(nil)).Parse(
+		`{{range .}}{{shortType .Name}} {{currency .OnDemandAnnual}} {{(family .Name).Year}}{{"\n"}}{{end}}`))
+	instances := []pricing.InstanceType{
+		{Name: "m5.large", OnDemandAnnual: 840.96},
+		{Name: "zz9.xlarge", OnDemandAnnual: 1500},
+	}
+
+	var buf bytes.Buffer
+	if err := renderTemplate(&buf, tmpl, instances, nil); err != nil {
+		t.Fatal(err)
+	}
+	exp := "m5.l $840.96 2017\nzz9.xl $1,500.00 0\n"
+	if buf.String() != exp {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), exp)
+	}
+
+	tmpl = template.Must(template.New("t").Funcs(templateFuncs(nil)).Parse(`{{range .}}{{dominatedBy .}};{{end}}`))
+	buf.Reset()
+	if err := renderTemplate(&buf, tmpl, instances, dominators{" m5.large": "m6g.large"}); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "m6g.large;;"; buf.String() != exp {
+		t.Errorf("got %q, want %q", buf.String(), exp)
+	}
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+// A predicate is a compiled -where expression.
+type predicate func(in pricing.InstanceType) bool
+
+// A whereError is a problem with a -where expression at a column (counted
+// in characters from 1).
+type whereError struct {
+	Col int
+	Msg string
+}
+
+func (e *whereError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Col, e.Msg)
+}
+
+// parseWhere compiles a -where expression. The grammar is
+//
+//	expr    = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | "(" expr ")" | operand [ op operand ]
+//	op      = "==" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "=~" | "!~"
+//	operand = field | number | "string" | true | false
+//
+// Numbers compare with numbers and strings with strings; ~ matches a string
+// against a glob and =~ and !~ against a regular expression. A field on its
+// own must be a bool. Comparisons with a missing price are false.
+func parseWhere(expr string) (predicate, error) {
+	pred, _, err := parseWhereFields(expr)
+	return pred, err
+}
+
+// parseWhereFields is parseWhere, also returning the fields expr uses.
+func parseWhereFields(expr string) (predicate, []field, error) {
+	toks, err := lexWhere(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &whereParser{toks: toks}
+	pred, err := p.expr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, nil, &whereError{t.col, fmt.Sprintf("unexpected %s", t)}
+	}
+	return pred, p.fields, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokNum
+	tokStr
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	text string // identifier, operator or unquoted string
+	num  float64
+	col  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokStr:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// whereOps are the operators, longest first so that "<=" is not lexed as
+// "<".
+var whereOps = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "~"}
+
+func lexWhere(expr string) ([]token, error) {
+	var toks []token
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		col := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, text: "(", col: col})
+			i++
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, text: ")", col: col})
+			i++
+		case r == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(rs) && rs[j] != '"'; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+				}
+				sb.WriteRune(rs[j])
+			}
+			if j == len(rs) {
+				return nil, &whereError{col, "unterminated string"}
+			}
+			toks = append(toks, token{kind: tokStr, text: sb.String(), col: col})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '.' || (r == '-' && i+1 < len(rs) && (unicode.IsDigit(rs[i+1]) || rs[i+1] == '.')):
+			j := i + 1
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.' || rs[j] == 'e' || rs[j] == 'E') {
+				j++
+			}
+			text := string(rs[i:j])
+			f, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &whereError{col, fmt.Sprintf("bad number %q", text)}
+			}
+			toks = append(toks, token{kind: tokNum, text: text, num: f, col: col})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_' || rs[j] == '.' || rs[j] == '-') {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, text: string(rs[i:j]), col: col})
+			i = j
+		default:
+			op := ""
+			for _, o := range whereOps {
+				if strings.HasPrefix(string(rs[i:]), o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, &whereError{col, fmt.Sprintf("unexpected %q", r)}
+			}
+			toks = append(toks, token{kind: tokOp, text: op, col: col})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF, col: len(rs) + 1}), nil
+}
+
+type whereParser struct {
+	toks   []token
+	pos    int
+	fields []field // used by the expression
+}
+
+func (p *whereParser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *whereParser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *whereParser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.text == op
+}
+
+func (p *whereParser) expr() (predicate, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(in pricing.InstanceType) bool { return l(in) || right(in) }
+	}
+	return left, nil
+}
+
+func (p *whereParser) and() (predicate, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(in pricing.InstanceType) bool { return l(in) && right(in) }
+	}
+	return left, nil
+}
+
+func (p *whereParser) unary() (predicate, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokOp && t.text == "!":
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(in pricing.InstanceType) bool { return !x(in) }, nil
+	case t.kind == tokLParen:
+		p.next()
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, &whereError{t.col, fmt.Sprintf("expected \")\", got %s", t)}
+		}
+		return x, nil
+	}
+	return p.comparison()
+}
+
+// An operand is a field or literal, evaluated to the value of its kind.
+type operand struct {
+	kind fieldKind
+	num  func(in pricing.InstanceType) float64
+	str  func(in pricing.InstanceType) string
+	bool func(in pricing.InstanceType) bool
+	tok  token
+}
+
+func (p *whereParser) operand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNum:
+		return operand{kind: numField, num: func(pricing.InstanceType) float64 { return t.num }, tok: t}, nil
+	case tokStr:
+		return operand{kind: strField, str: func(pricing.InstanceType) string { return t.text }, tok: t}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			b := t.text == "true"
+			return operand{kind: boolField, bool: func(pricing.InstanceType) bool { return b }, tok: t}, nil
+		}
+		f, ok := lookupField(t.text)
+		if !ok {
+			return operand{}, &whereError{t.col, fmt.Sprintf("unknown field %q (fields: %s, or a column)", t.text, strings.Join(sortedFieldNames(), ", "))}
+		}
+		p.fields = append(p.fields, f)
+		return operand{kind: f.kind, num: f.num, str: f.str, bool: f.bool, tok: t}, nil
+	}
+	return operand{}, &whereError{t.col, fmt.Sprintf("expected a field or value, got %s", t)}
+}
+
+func (p *whereParser) comparison() (predicate, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.peek()
+	switch opTok.text {
+	case "==", "!=", "<", "<=", ">", ">=", "~", "=~", "!~":
+		if opTok.kind != tokOp {
+			break
+		}
+		p.next()
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return compare(left, opTok, right)
+	}
+
+	if left.kind != boolField {
+		return nil, &whereError{left.tok.col, fmt.Sprintf("%s is a %s, not a condition", left.tok, left.kind)}
+	}
+	return left.bool, nil
+}
+
+func compare(left operand, op token, right operand) (predicate, error) {
+	switch op.text {
+	case "~", "=~", "!~":
+		if left.kind != strField {
+			return nil, &whereError{left.tok.col, fmt.Sprintf("%s needs a string on the left, got %s %s", op.text, left.kind, left.tok)}
+		}
+		if right.tok.kind != tokStr {
+			return nil, &whereError{right.tok.col, fmt.Sprintf("%s needs a quoted pattern on the right, got %s", op.text, right.tok)}
+		}
+		pattern := right.tok.text
+		if op.text == "~" {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, &whereError{right.tok.col, fmt.Sprintf("bad glob %q: %s", pattern, err)}
+			}
+			return func(in pricing.InstanceType) bool {
+				ok, _ := path.Match(pattern, left.str(in))
+				return ok
+			}, nil
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, &whereError{right.tok.col, fmt.Sprintf("bad regular expression: %s", err)}
+		}
+		want := op.text == "=~"
+		return func(in pricing.InstanceType) bool { return re.MatchString(left.str(in)) == want }, nil
+	}
+
+	if left.kind != right.kind {
+		return nil, &whereError{right.tok.col, fmt.Sprintf("cannot compare %s %s with %s %s", left.kind, left.tok, right.kind, right.tok)}
+	}
+
+	switch left.kind {
+	case numField:
+		var cmp func(a, b float64) bool
+		switch op.text {
+		case "==":
+			cmp = func(a, b float64) bool { return a == b }
+		case "!=":
+			cmp = func(a, b float64) bool { return a != b }
+		case "<":
+			cmp = func(a, b float64) bool { return a < b }
+		case "<=":
+			cmp = func(a, b float64) bool { return a <= b }
+		case ">":
+			cmp = func(a, b float64) bool { return a > b }
+		case ">=":
+			cmp = func(a, b float64) bool { return a >= b }
+		}
+		return func(in pricing.InstanceType) bool { return cmp(left.num(in), right.num(in)) }, nil
+	case strField:
+		var cmp func(a, b string) bool
+		switch op.text {
+		case "==":
+			cmp = func(a, b string) bool { return a == b }
+		case "!=":
+			cmp = func(a, b string) bool { return a != b }
+		case "<":
+			cmp = func(a, b string) bool { return a < b }
+		case "<=":
+			cmp = func(a, b string) bool { return a <= b }
+		case ">":
+			cmp = func(a, b string) bool { return a > b }
+		case ">=":
+			cmp = func(a, b string) bool { return a >= b }
+		}
+		return func(in pricing.InstanceType) bool { return cmp(left.str(in), right.str(in)) }, nil
+	}
+
+	switch op.text {
+	case "==":
+		return func(in pricing.InstanceType) bool { return left.bool(in) == right.bool(in) }, nil
+	case "!=":
+		return func(in pricing.InstanceType) bool { return left.bool(in) != right.bool(in) }, nil
+	}
+	return nil, &whereError{op.col, fmt.Sprintf("cannot use %s on bools", op.text)}
+}
+
+// mustParseWhere compiles a -where expression, exiting with the expression
+// and a marker under the problem if it does not parse. opts are adjusted
+// for the fields it uses. An empty expression is a nil predicate.
+func mustParseWhere(expr string, opts *pricing.Options) predicate {
+	if expr == "" {
+		return nil
+	}
+	pred, fs, err := parseWhereFields(expr)
+	if err != nil {
+		var col int
+		if we, ok := err.(*whereError); ok {
+			col = we.Col
+		}
+		log.Fatalf("-where: %s\n  %s\n  %s^", err, expr, strings.Repeat(" ", max(col-1, 0)))
+	}
+	setupFields(opts, fs...)
+	return pred
+}
+
+// filterWhere returns the instances keep matches, reusing the backing array
+// of instances, or all of them if keep is nil.
+func filterWhere(instances []pricing.InstanceType, keep predicate) []pricing.InstanceType {
+	if keep == nil {
+		return instances
+	}
+	kept := instances[:0]
+	for _, in := range instances {
+		if keep(in) {
+			kept = append(kept, in)
+		}
+	}
+	return kept
+}
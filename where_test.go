@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/psanford/ec2price/pricing"
+)
+
+func TestWhere(t *testing.T) {
+	in := pricing.InstanceType{
+		Name:        "m7gd.xlarge",
+		Family:      "m7gd",
+		VCPU:        4,
+		Memory:      16,
+		Disk:        pricing.Disk{Count: 1, PerDiskGB: 237, SSD: true, NVMe: true},
+		Hourly:      0.2136,
+		CPUMfgr:     pricing.CPUAWS,
+		CurrentGen:  true,
+		NetworkPerf: pricing.NetworkPerf{CapGb: 12.5, Bursting: true},
+	}
+
+	tests := []struct {
+		expr string
+		exp  bool
+	}{
+		{`mem >= 16 && vcpu >= 4 && mfg == "arm" && disk.nvme && net >= 12.5`, true},
+		{`mem >= 16 && vcpu >= 8`, false},
+		{`vcpu >= 8 || net.burst`, true},
+		{`!disk.ebs`, true},
+		{`!(disk.nvme && current)`, false},
+		{`name ~ "m7g*.*large"`, true},
+		{`name ~ "m7g.*"`, false},
+		{`name =~ "^m7gd?\\."`, true},
+		{`family !~ "d$"`, false},
+		{`disk == 237 && disk.count != 2`, true},
+		{`hourly < .25 && hourly > -1`, true},
+		{`reserved > 0`, false},
+		{`reserved <= 0`, false},
+		{`disk.ssd == true`, true},
+	}
+	for _, test := range tests {
+		pred, err := parseWhere(test.expr)
+		if err != nil {
+			t.Errorf("%s: %s", test.expr, err)
+			continue
+		}
+		if got := pred(in); got != test.exp {
+			t.Errorf("%s: got=%t exp=%t", test.expr, got, test.exp)
+		}
+	}
+}
+
+func TestWhereErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		col  int
+	}{
+		{`mem >= 16 && vcpux >= 4`, 14},
+		{`mem >= "16"`, 8},
+		{`vcpu`, 1},
+		{`mem >= 16 &&`, 13},
+		{`(mem >= 16`, 11},
+		{`mem >= 16)`, 10},
+		{`name =~ "("`, 9},
+		{`mfg == "arm`, 8},
+		{`vcpu ~ "4"`, 1},
+		{`mem # 4`, 5},
+		{`disk.nvme < true`, 11},
+	}
+	for _, test := range tests {
+		_, err := parseWhere(test.expr)
+		we, ok := err.(*whereError)
+		if !ok {
+			t.Errorf("%s: got err=%v, exp whereError", test.expr, err)
+			continue
+		}
+		if we.Col != test.col {
+			t.Errorf("%s: %s: got col=%d exp=%d", test.expr, we, we.Col, test.col)
+		}
+	}
+}
+
+func TestWhereColumns(t *testing.T) {
+	in := pricing.InstanceType{Name: "m5.large", VCPU: 2, Memory: 8, Hourly: 0.096, ReservedAnnual: 600}
+	for expr, exp := range map[string]bool{
+		"annual-reserved < 700": true,
+		"vcpu-hr > 0.05":        false,
+		"monthly > 70":          true,
+	} {
+		pred, err := parseWhere(expr)
+		if err != nil {
+			t.Fatalf("%s: %s", expr, err)
+		}
+		if got := pred(in); got != exp {
+			t.Errorf("%s: got %t, want %t", expr, got, exp)
+		}
+	}
+
+	var opts pricing.Options
+	mustParseWhere("os-premium > 0", &opts)
+	if !opts.LinuxPremium {
+		t.Error("os-premium did not set LinuxPremium")
+	}
+}
+
+func TestFilterWhere(t *testing.T) {
+	instances := []pricing.InstanceType{{Name: "m5.large", VCPU: 2}, {Name: "m5.xlarge", VCPU: 4}, {Name: "m5.2xlarge", VCPU: 8}}
+	if got := filterWhere(instances, nil); len(got) != 3 {
+		t.Errorf("nil predicate kept %d, want 3", len(got))
+	}
+	got := filterWhere(instances, mustParseWhere("vcpu >= 4", &pricing.Options{}))
+	if len(got) != 2 || got[0].Name != "m5.xlarge" || got[1].Name != "m5.2xlarge" {
+		t.Errorf("vcpu >= 4 kept %+v", got)
+	}
+	if mustParseWhere("", &pricing.Options{}) != nil {
+		t.Error("empty -where is not nil")
+	}
+}